@@ -8,6 +8,7 @@ require (
 	github.com/google/gofuzz v1.2.0 // indirect
 	github.com/kudobuilder/kudo v0.17.1
 	github.com/mattn/go-colorable v0.1.8 // indirect
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/afero v1.4.1
 	github.com/spf13/cobra v1.1.1 // indirect
 	github.com/stretchr/testify v1.6.1
@@ -21,4 +22,5 @@ require (
 	k8s.io/client-go v0.19.3
 	k8s.io/klog/v2 v2.3.0 // indirect
 	k8s.io/utils v0.0.0-20201015054608-420da100c033 // indirect
+	sigs.k8s.io/yaml v1.2.0
 )
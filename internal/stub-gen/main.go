@@ -1,59 +1,75 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
+	"go/format"
+	"io/ioutil"
 	"os"
+	"reflect"
 	"strings"
 	"text/template"
+
+	kudo "github.com/kudobuilder/kudo/pkg/client/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 )
 
 //nolint:lll
-const stubTemplate = `package kubernetes
+const stubTemplate = `package {{ .Package }}
 
 // Code generated by stub-gen; DO NOT EDIT.
 
 import (
 	"fmt"
-{{ if eq .API "CoreV1" }}
-	corev1 "k8s.io/api/core/v1"{{ else  if eq .API "AppsV1" }}
-	appsv1 "k8s.io/api/apps/v1"{{ else  if eq .API "RbacV1" }}
-	rbacv1 "k8s.io/api/rbac/v1"{{ end }}
+	"sort"
+	"sync"
+
+	{{ .API | toLower }} "{{ .Import }}"{{ if ne .API "CoreV1" }}
+	corev1 "k8s.io/api/core/v1"{{ end }}
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
-	"github.com/kudobuilder/test-tools/pkg/client"
+	"github.com/kudobuilder/test-tools/pkg/client"{{ if ne .Package "kubernetes" }}
+	"github.com/kudobuilder/test-tools/pkg/kubernetes"{{ end }}
 )
 
-// {{ .Type }} wraps a Kubernetes {{ .Type }}.
+// {{ .Type }} wraps a {{ .Client }} {{ .Type }}.
 type {{ .Type }} struct {
 	{{ .API | toLower }}.{{ .Type }}
 
 	client client.Client
 }
 
-// New{{ .Type }} creates a {{ .Type }} from its Kubernetes {{ .Type }}.
+// New{{ .Type }} creates a {{ .Type }} from its {{ .Client }} {{ .Type }}.
 func New{{ .Type }}(client client.Client, {{ .Type | toLower }} {{ .API | toLower }}.{{ .Type }}) ({{ .Type }}, error) {
-	created{{ .Type }}, err := client.Kubernetes.
+	created{{ .Type }}, err := client.{{ .Client }}.
 		{{ .API }}().
-		{{ .Type }}s({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{end}}).
+		{{ .Resource }}({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{end}}).
 		Create(client.Ctx, &{{ .Type | toLower }}, metav1.CreateOptions{})
 	if err != nil {
 		return {{ .Type }}{}, fmt.Errorf("failed to create {{ .Type | toLower }} %s{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ .Type | toLower }}.Name{{ if .HasNamespace }}, {{ .Type | toLower}}.Namespace{{ end }}, err)
 	}
 
-	return {{ .Type }}{
+	result := {{ .Type }}{
 		{{ .Type }}: *created{{ .Type }},
 		client: client,
-	}, nil
+	}
+
+	client.Track(fmt.Sprintf("{{ .Type | toLower }} %s{{ if .HasNamespace }} in namespace %s{{ end }}", result.Name{{ if .HasNamespace }}, result.Namespace{{ end }}), result.Delete)
+
+	return result, nil
 }
 
 // Get{{ .Type }} gets a {{ .Type | toLower }}{{ if .HasNamespace }} in a namespace{{ end }}.
 func Get{{ .Type }}(client client.Client, name string{{ if .HasNamespace }}, namespace string{{ end }}) ({{ .Type }}, error) {
 	options := metav1.GetOptions{}
 
-	{{ .Type | toLower }}, err := client.Kubernetes.
+	{{ .Type | toLower }}, err := client.{{ .Client }}.
 		{{ .API }}().
-		{{ .Type }}s({{ if .HasNamespace }}namespace{{ end }}).
+		{{ .Resource }}({{ if .HasNamespace }}namespace{{ end }}).
 		Get(client.Ctx, name, options)
 	if err != nil {
 		return {{ .Type }}{}, fmt.Errorf("failed to get {{ .Type | toLower }} %s{{ if .HasNamespace }} in namespace %s{{ end }}: %w", name{{ if .HasNamespace }}, namespace{{ end }}, err)
@@ -65,37 +81,120 @@ func Get{{ .Type }}(client client.Client, name string{{ if .HasNamespace }}, nam
 	}, nil
 }
 
-// List{{ .Type }}s lists all {{ .Type | toLower}}s{{ if .HasNamespace }} in a namespace{{ end }}.
-func List{{.Type}}s(client client.Client{{ if .HasNamespace }}, namespace string{{ end }}) ([]{{ .Type }}, error) {
+// List{{ .Resource }} lists all {{ .Resource | toLower }}{{ if .HasNamespace }} in a namespace{{ end }}.
+func List{{ .Resource }}(client client.Client{{ if .HasNamespace }}, namespace string{{ end }}) ([]{{ .Type }}, error) {
 	options := metav1.ListOptions{}
 
-	list, err := client.Kubernetes.
+	list, err := client.{{ .Client }}.
 		{{ .API }}().
-		{{ .Type }}s({{ if .HasNamespace }}namespace{{ end }}).
+		{{ .Resource }}({{ if .HasNamespace }}namespace{{ end }}).
 		List(client.Ctx, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list {{ .Type | toLower }}s{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ if .HasNamespace }}namespace, {{ end }}err)
+		return nil, fmt.Errorf("failed to list {{ .Resource | toLower }}{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ if .HasNamespace }}namespace, {{ end }}err)
 	}
 
-	{{ .Type | toLower }}s := make([]{{ .Type }}, 0, len(list.Items))
+	{{ .Resource | toLower }} := make([]{{ .Type }}, 0, len(list.Items))
 
 	for _, item := range list.Items {
-		{{ .Type | toLower }}s = append({{ .Type | toLower }}s, {{ .Type }}{
+		{{ .Resource | toLower }} = append({{ .Resource | toLower }}, {{ .Type }}{
 			{{ .Type }}: item,
 			client: client,
 		})
 	}
 
-	return {{ .Type | toLower }}s, nil
+	return {{ .Resource | toLower }}, nil
+}
+
+// {{ .Type }}Event is an event received while watching {{ .Resource | toLower }}.
+type {{ .Type }}Event struct {
+	Type watch.EventType
+	{{ .Type }} {{ .Type }}
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// Watch{{ .Resource }} watches {{ .Resource | toLower }}{{ if .HasNamespace }} in a namespace{{ end }}.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func Watch{{ .Resource }}(client client.Client{{ if .HasNamespace }}, namespace string{{ end }}, options metav1.ListOptions) (<-chan {{ .Type }}Event, func(), error) {
+	watcher, err := client.{{ .Client }}.
+		{{ .API }}().
+		{{ .Resource }}({{ if .HasNamespace }}namespace{{ end }}).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch {{ .Resource | toLower }}{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ if .HasNamespace }}namespace, {{ end }}err)
+	}
+
+	events := make(chan {{ .Type }}Event)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := {{ .Type }}Event{Type: event.Type}
+
+			object, ok := event.Object.(*{{ .API | toLower }}.{{ .Type }})
+			if ok {
+				result.{{ .Type }} = {{ .Type }}{
+					{{ .Type }}: *object,
+					client: client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch {{ .Resource | toLower }}{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ if .HasNamespace }}namespace, {{ end }}apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the {{ .Type }}, ordered by their first occurrence.
+// Events of an earlier {{ .Type | toLower }} with the same name are not included.
+func ({{ .Type | toLower }} {{ .Type }}) Events() ([]corev1.Event, error) {
+	events, err := {{ .Kubernetes }}ListEvents(
+		{{ .Type | toLower }}.client,
+		{{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ else }}metav1.NamespaceAll{{ end }},
+		{{ .Kubernetes }}EventInvolvedObject("{{ .Type }}", {{ .Type | toLower }}.Name),
+		{{ .Kubernetes }}EventInvolvedUID({{ .Type | toLower }}.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return {{ .Kubernetes }}EventFirstTime(events[i]).Before({{ .Kubernetes }}EventFirstTime(events[j]))
+	})
+
+	return events, nil
 }
 
 // Delete deletes a {{ .Type }} from the Kubernetes cluster.
 func ({{ .Type | toLower }} {{ .Type }}) Delete() error {
 	options := metav1.DeleteOptions{}
 
-	err := {{ .Type | toLower }}.client.Kubernetes.
+	err := {{ .Type | toLower }}.client.{{ .Client }}.
 		{{ .API }}().
-		{{ .Type }}s({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ end }}).
+		{{ .Resource }}({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ end }}).
 		Delete({{ .Type | toLower}}.client.Ctx, {{ .Type | toLower }}.Name, options)
 	if err != nil {
 		return fmt.Errorf("failed to delete {{ .Type | toLower }} %s{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ .Type | toLower }}.Name, {{ if .HasNamespace }}{{ .Type | toLower}}.Namespace, {{ end }}err)
@@ -108,9 +207,9 @@ func ({{ .Type | toLower }} {{ .Type }}) Delete() error {
 func ({{ .Type | toLower }} *{{ .Type }}) Update() error {
 	options := metav1.GetOptions{}
 
-	update, err := {{ .Type | toLower }}.client.Kubernetes.
+	update, err := {{ .Type | toLower }}.client.{{ .Client }}.
 		{{ .API }}().
-		{{ .Type }}s({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ end }}).
+		{{ .Resource }}({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ end }}).
 		Get({{ .Type | toLower}}.client.Ctx, {{ .Type | toLower }}.Name, options)
 	if err != nil {
 		return fmt.Errorf("failed to update {{ .Type | toLower }} %s{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ .Type | toLower }}.Name, {{ if .HasNamespace }}{{ .Type | toLower}}.Namespace, {{ end }}err)
@@ -123,9 +222,9 @@ func ({{ .Type | toLower }} *{{ .Type }}) Update() error {
 
 // Save saves the current {{ .Type }}.
 func ({{ .Type | toLower }} *{{ .Type }}) Save() error {
-	update, err := {{ .Type | toLower }}.client.Kubernetes.
+	update, err := {{ .Type | toLower }}.client.{{ .Client }}.
 		{{ .API }}().
-		{{ .Type }}s({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ end }}).
+		{{ .Resource }}({{ if .HasNamespace }}{{ .Type | toLower }}.Namespace{{ end }}).
 		Update({{ .Type | toLower}}.client.Ctx, &{{ .Type | toLower }}.{{ .Type }}, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to save {{ .Type | toLower }} %s{{ if .HasNamespace }} in namespace %s{{ end }}: %w", {{ .Type | toLower }}.Name, {{ if .HasNamespace }}{{ .Type | toLower}}.Namespace, {{ end }}err)
@@ -137,46 +236,341 @@ func ({{ .Type | toLower }} *{{ .Type }}) Save() error {
 }
 `
 
+//nolint:lll
+const testTemplate = `package {{ .Package }}
+
+// Code generated by stub-gen; DO NOT EDIT.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	{{ .API | toLower }} "{{ .Import }}"{{ if ne .API "CoreV1" }}
+	corev1 "k8s.io/api/core/v1"{{ end }}
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"{{ if ne .Client "Kubernetes" }}
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"{{ end }}
+	k8stesting "k8s.io/client-go/testing"
+	"{{ .FakeImport }}"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func TestGenerated{{ .Type }}(t *testing.T) {
+	client := client.Client{
+		Ctx: context.TODO(),
+		{{ .Client }}: fake.NewSimpleClientset(),
+	}
+
+	created, err := New{{ .Type }}(client, {{ .API | toLower }}.{{ .Type }}{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-{{ .Type | toLower }}",{{ if .HasNamespace }}
+			Namespace: "test",{{ end }}
+		},
+	})
+	assert.NoError(t, err)
+
+	got, err := Get{{ .Type }}(client, created.Name{{ if .HasNamespace }}, created.Namespace{{ end }})
+	assert.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	list, err := List{{ .Resource }}(client{{ if .HasNamespace }}, created.Namespace{{ end }})
+	assert.NoError(t, err)
+	assert.Equal(t, []{{ .Type }}{created}, list)
+
+	got.Labels = map[string]string{"generated-by": "stub-gen"}
+	assert.NoError(t, got.Save())
+
+	assert.NoError(t, created.Update())
+	assert.Equal(t, got.Labels, created.Labels)
+
+	assert.NoError(t, created.Delete())
+
+	_, err = Get{{ .Type }}(client, created.Name{{ if .HasNamespace }}, created.Namespace{{ end }})
+	assert.Error(t, err)
+}
+
+func TestGenerated{{ .Type }}Watch(t *testing.T) {
+	client := client.Client{
+		Ctx: context.TODO(),
+		{{ .Client }}: fake.NewSimpleClientset(),
+	}
+
+	events, stop, err := Watch{{ .Resource }}(client{{ if .HasNamespace }}, "test"{{ end }}, metav1.ListOptions{})
+	assert.NoError(t, err)
+
+	created, err := New{{ .Type }}(client, {{ .API | toLower }}.{{ .Type }}{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-{{ .Type | toLower }}",{{ if .HasNamespace }}
+			Namespace: "test",{{ end }}
+		},
+	})
+	assert.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, created, event.{{ .Type }})
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for watch event")
+	}
+
+	stop()
+	stop()
+
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestGenerated{{ .Type }}WatchError(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	watcher := watch.NewFake()
+	clientset.PrependWatchReactor("*", k8stesting.DefaultWatchReactor(watcher, nil))
+
+	client := client.Client{
+		Ctx: context.TODO(),
+		{{ .Client }}: clientset,
+	}
+
+	events, stop, err := Watch{{ .Resource }}(client{{ if .HasNamespace }}, "test"{{ end }}, metav1.ListOptions{})
+	assert.NoError(t, err)
+
+	defer stop()
+
+	go watcher.Error(&metav1.Status{
+		Status: metav1.StatusFailure,
+		Reason: metav1.StatusReasonExpired,
+		Code: 410,
+	})
+
+	select {
+	case event := <-events:
+		assert.Equal(t, watch.Error, event.Type)
+		assert.True(t, apierrors.IsResourceExpired(event.Err), "unexpected error %v", event.Err)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for watch error")
+	}
+
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestGenerated{{ .Type }}Events(t *testing.T) {
+	event := func(name string, kind string, involved string, uid types.UID, firstTimestamp time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Namespace: "{{ if .HasNamespace }}test{{ else }}default{{ end }}",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind: kind,
+				Name: involved,
+				UID: uid,
+			},
+			FirstTimestamp: metav1.NewTime(firstTimestamp),
+		}
+	}
+
+	now := time.Now()
+
+	client := client.Client{
+		Ctx: context.TODO(),{{ if eq .Client "Kubernetes" }}
+		Kubernetes: fake.NewSimpleClientset(
+			event("second", "{{ .Type }}", "test-{{ .Type | toLower }}", "test-uid", now),
+			event("first", "{{ .Type }}", "test-{{ .Type | toLower }}", "test-uid", now.Add(-time.Minute)),
+			event("other-name", "{{ .Type }}", "other", "other-uid", now),
+			event("other-kind", "Other", "test-{{ .Type | toLower }}", "test-uid", now),
+			event("other-uid", "{{ .Type }}", "test-{{ .Type | toLower }}", "earlier-uid", now),
+		),{{ else }}
+		Kubernetes: kubernetesfake.NewSimpleClientset(
+			event("second", "{{ .Type }}", "test-{{ .Type | toLower }}", "test-uid", now),
+			event("first", "{{ .Type }}", "test-{{ .Type | toLower }}", "test-uid", now.Add(-time.Minute)),
+			event("other-name", "{{ .Type }}", "other", "other-uid", now),
+			event("other-kind", "Other", "test-{{ .Type | toLower }}", "test-uid", now),
+			event("other-uid", "{{ .Type }}", "test-{{ .Type | toLower }}", "earlier-uid", now),
+		),
+		{{ .Client }}: fake.NewSimpleClientset(),{{ end }}
+	}
+
+	created, err := New{{ .Type }}(client, {{ .API | toLower }}.{{ .Type }}{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-{{ .Type | toLower }}",{{ if .HasNamespace }}
+			Namespace: "test",{{ end }}
+			UID: "test-uid",
+		},
+	})
+	assert.NoError(t, err)
+
+	events, err := created.Events()
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.Name)
+	}
+
+	assert.Equal(t, []string{"first", "second"}, names)
+}
+`
+
+// config lists the types stub-gen generates wrappers for.
+type config struct {
+	Types []struct {
+		API  string `json:"api"`
+		Type string `json:"type"`
+	} `json:"types"`
+}
+
 type parameters struct {
+	Package      string
+	Client       string
 	API          string
 	Type         string
+	Resource     string
+	Import       string
+	FakeImport   string
 	HasNamespace bool
+	// Kubernetes qualifies the helpers of the kubernetes package outside of it, e.g. "kubernetes.".
+	Kubernetes string
 }
 
-// stub-gen creates common function for Kubernetes object wrappers.
-func main() {
-	var parameters parameters
+// clientset is a typed clientset available in client.Client.
+type clientset struct {
+	field string
+	iface reflect.Type
+}
 
-	flag.StringVar(&parameters.API, "api", "", "kubernetes API")
-	flag.StringVar(&parameters.Type, "type", "", "type to generate")
+// resolve looks up a type in the typed clientsets of client.Client. The API group interface is expected to
+// have a method returning a client whose Get method returns the type, e.g. CoreV1().Pods(namespace).Get(...).
+// The name of that method is used as the resource name, and the type is namespaced if it takes a namespace.
+func resolve(packageName string, api string, typeName string) (parameters, error) {
+	clientsets := []clientset{
+		{"Kubernetes", reflect.TypeOf((*kubernetes.Interface)(nil)).Elem()},
+		{"Kudo", reflect.TypeOf((*kudo.Interface)(nil)).Elem()},
+	}
 
-	flag.BoolVar(&parameters.HasNamespace, "hasNamespace", true, "type uses namespace")
+	for _, clientset := range clientsets {
+		group, ok := clientset.iface.MethodByName(api)
+		if !ok {
+			continue
+		}
 
-	flag.Parse()
+		groupInterface := group.Type.Out(0)
+
+		for i := 0; i < groupInterface.NumMethod(); i++ {
+			method := groupInterface.Method(i)
+
+			if method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Interface {
+				continue
+			}
+
+			get, ok := method.Type.Out(0).MethodByName("Get")
+			if !ok || get.Type.NumOut() != 2 || get.Type.Out(0).Kind() != reflect.Ptr {
+				continue
+			}
+
+			object := get.Type.Out(0).Elem()
+			if object.Name() != typeName {
+				continue
+			}
+
+			return parameters{
+				Package:      packageName,
+				Client:       clientset.field,
+				API:          api,
+				Type:         typeName,
+				Resource:     method.Name,
+				Import:       object.PkgPath(),
+				FakeImport:   clientset.iface.PkgPath() + "/fake",
+				HasNamespace: method.Type.NumIn() == 1,
+				Kubernetes:   kubernetesQualifier(packageName),
+			}, nil
+		}
 
-	funcMap := template.FuncMap{
-		"toLower": strings.ToLower,
+		return parameters{}, fmt.Errorf("API %s has no type %s", api, typeName)
+	}
+
+	return parameters{}, fmt.Errorf("no clientset provides API %s", api)
+}
+
+func kubernetesQualifier(packageName string) string {
+	if packageName == "kubernetes" {
+		return ""
+	}
+
+	return "kubernetes."
+}
+
+func generate(tmpl *template.Template, outputName string, parameters parameters) error {
+	var buffer bytes.Buffer
+
+	if err := tmpl.Execute(&buffer, parameters); err != nil {
+		return fmt.Errorf("failed to execute template for %s: %w", outputName, err)
 	}
 
-	tmpl, err := template.New("stub").Funcs(funcMap).Parse(stubTemplate)
+	source, err := format.Source(buffer.Bytes())
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to format %s: %w", outputName, err)
 	}
 
-	outputName := fmt.Sprintf("%s.generated.go", strings.ToLower(parameters.Type))
+	return ioutil.WriteFile(outputName, source, 0644) //nolint:gosec
+}
 
-	output, err := os.Create(outputName)
+func run(configPath string, packageName string) error {
+	data, err := ioutil.ReadFile(configPath)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to read config %s: %w", configPath, err)
 	}
 
-	defer func() {
-		if err := output.Close(); err != nil {
-			panic(err)
+	var config config
+
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", configPath, err)
+	}
+
+	funcMap := template.FuncMap{
+		"toLower": strings.ToLower,
+	}
+
+	stub := template.Must(template.New("stub").Funcs(funcMap).Parse(stubTemplate))
+	test := template.Must(template.New("test").Funcs(funcMap).Parse(testTemplate))
+
+	for _, t := range config.Types {
+		parameters, err := resolve(packageName, t.API, t.Type)
+		if err != nil {
+			return err
 		}
-	}()
 
-	if err := tmpl.Execute(output, parameters); err != nil {
-		panic(err)
+		baseName := strings.ToLower(t.Type)
+
+		if err := generate(stub, baseName+".generated.go", parameters); err != nil {
+			return err
+		}
+
+		if err := generate(test, baseName+".generated_test.go", parameters); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stub-gen creates common functions and tests for the Kubernetes object wrappers listed in a config file.
+// Namespacing, resource names and imports are looked up from the typed clientsets of client.Client.
+func main() {
+	var configPath, packageName string
+
+	flag.StringVar(&configPath, "config", "stub-gen.yaml", "config file listing the types to generate")
+	flag.StringVar(&packageName, "package", os.Getenv("GOPACKAGE"), "package of the generated files")
+
+	flag.Parse()
+
+	if err := run(configPath, packageName); err != nil {
+		fmt.Fprintf(os.Stderr, "stub-gen: %v\n", err)
+		os.Exit(1)
 	}
 }
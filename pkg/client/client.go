@@ -5,8 +5,12 @@ import (
 	"context"
 
 	kudo "github.com/kudobuilder/kudo/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -15,58 +19,113 @@ type Client struct {
 	Ctx        context.Context
 	Kubernetes kubernetes.Interface
 	Kudo       kudo.Interface
+	Dynamic    dynamic.Interface
+	RESTMapper meta.RESTMapper
 	Config     rest.Config
 	// may be empty in the "in cluster" case
 	KubeConfigPath string
+	// records created resources if set
+	Tracker *Tracker
+	// replaces pod exec and logs if set
+	PodStreams PodStreams
 }
 
 // NewForConfig creates a Client using a kubeconfig path.
-func NewForConfig(kubeconfigPath string) (Client, error) {
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+// Additional options can be used to select a kubeconfig context or to change the client configuration.
+//   c, err := client.NewForConfig(kubeconfigPath,
+//   	client.KubeContext("staging"),
+//   	client.RateLimit(50, 100))
+func NewForConfig(kubeconfigPath string, options ...Option) (Client, error) {
+	opts := newOptions(options)
+
+	var restConfig *rest.Config
+
+	var err error
+
+	if opts.KubeContext == "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	} else {
+		restConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+			&clientcmd.ConfigOverrides{CurrentContext: opts.KubeContext}).ClientConfig()
+	}
+
 	if err != nil {
 		return Client{}, err
 	}
 
-	kubernetesClient, err := kubernetes.NewForConfig(config)
+	client, err := newForRestConfig(restConfig, opts)
 	if err != nil {
 		return Client{}, err
 	}
 
-	kudoClient, err := kudo.NewForConfig(config)
+	client.KubeConfigPath = kubeconfigPath
+
+	return client, nil
+}
+
+// NewInCluster creates a Client using the service account Kubernetes gives to pods.
+func NewInCluster(options ...Option) (Client, error) {
+	restConfig, err := rest.InClusterConfig()
 	if err != nil {
 		return Client{}, err
 	}
 
-	return Client{
-		Ctx:            context.TODO(),
-		Kubernetes:     kubernetesClient,
-		Kudo:           kudoClient,
-		Config:         *config,
-		KubeConfigPath: kubeconfigPath,
-	}, nil
+	return newForRestConfig(restConfig, newOptions(options))
 }
 
-// NewInCluster creates a Client using the service account Kubernetes gives to pods.
-func NewInCluster() (Client, error) {
-	config, err := rest.InClusterConfig()
+// NewForRestConfig creates a Client from an existing REST configuration.
+// This can be used to create a client impersonating another identity, e.g. to test RBAC rules:
+//   restricted, err := client.NewForRestConfig(&c.Config,
+//   	client.ImpersonateServiceAccount("kafka", "kafka-client"))
+func NewForRestConfig(restConfig *rest.Config, options ...Option) (Client, error) {
+	return newForRestConfig(restConfig, newOptions(options))
+}
+
+func newForRestConfig(restConfig *rest.Config, options Options) (Client, error) {
+	restConfig = rest.CopyConfig(restConfig)
+
+	if options.QPS != 0 {
+		restConfig.QPS = options.QPS
+	}
+
+	if options.Burst != 0 {
+		restConfig.Burst = options.Burst
+	}
+
+	if options.Timeout != 0 {
+		restConfig.Timeout = options.Timeout
+	}
+
+	if options.Impersonate.UserName != "" {
+		restConfig.Impersonate = options.Impersonate
+	}
+
+	kubernetesClient, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		return Client{}, err
 	}
 
-	kubernetesClient, err := kubernetes.NewForConfig(config)
+	kudoClient, err := kudo.NewForConfig(restConfig)
 	if err != nil {
 		return Client{}, err
 	}
 
-	kudoClient, err := kudo.NewForConfig(config)
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
 		return Client{}, err
 	}
 
+	// The discovered API resources are cached, which keeps mapping kinds to resources cheap.
+	// Kinds that are unknown to the cache, e.g. of CRDs created later, cause a refresh of the cache.
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubernetesClient.Discovery()))
+
 	return Client{
-		Ctx:        context.TODO(),
+		Ctx:        options.Ctx,
 		Kubernetes: kubernetesClient,
 		Kudo:       kudoClient,
-		Config:     *config,
+		Dynamic:    dynamicClient,
+		RESTMapper: restMapper,
+		Config:     *restConfig,
 	}, nil
 }
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: first
+  cluster:
+    server: https://first.example.com
+- name: second
+  cluster:
+    server: https://second.example.com
+contexts:
+- name: first
+  context:
+    cluster: first
+- name: second
+  context:
+    cluster: second
+current-context: first
+`
+
+func TestNewForConfig(t *testing.T) {
+	directory, err := ioutil.TempDir("", "client")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer os.RemoveAll(directory)
+
+	kubeconfigPath := path.Join(directory, "kubeconfig")
+	assert.NoError(t, ioutil.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600))
+
+	client, err := NewForConfig(kubeconfigPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://first.example.com", client.Config.Host)
+	assert.Equal(t, kubeconfigPath, client.KubeConfigPath)
+
+	client, err = NewForConfig(kubeconfigPath, KubeContext("second"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://second.example.com", client.Config.Host)
+	assert.Equal(t, kubeconfigPath, client.KubeConfigPath)
+
+	_, err = NewForConfig(kubeconfigPath, KubeContext("missing"))
+	assert.Error(t, err)
+}
+
+func TestNewForRestConfig(t *testing.T) {
+	type key struct{}
+
+	ctx := context.WithValue(context.TODO(), key{}, "parent")
+	config := &rest.Config{
+		Host: "https://example.com",
+	}
+
+	client, err := NewForRestConfig(config,
+		ParentContext(ctx),
+		RateLimit(50, 100),
+		RequestTimeout(time.Minute),
+		ImpersonateServiceAccount("kafka", "client"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, ctx, client.Ctx)
+	assert.Equal(t, float32(50), client.Config.QPS)
+	assert.Equal(t, 100, client.Config.Burst)
+	assert.Equal(t, time.Minute, client.Config.Timeout)
+	assert.Equal(t, rest.ImpersonationConfig{
+		UserName: "system:serviceaccount:kafka:client",
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:kafka"},
+	}, client.Config.Impersonate)
+
+	// The original configuration isn't changed.
+	assert.Equal(t, rest.Config{Host: "https://example.com"}, *config)
+}
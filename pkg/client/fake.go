@@ -0,0 +1,275 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	kudofake "github.com/kudobuilder/kudo/pkg/client/clientset/versioned/fake"
+	kudoscheme "github.com/kudobuilder/kudo/pkg/client/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/kudobuilder/test-tools/pkg/cmd"
+)
+
+// Fake is a Client backed by fake clientsets, for unit tests of code using test-tools.
+// Commands executed in containers are handled by a cmd.FakeExecutor.
+// Unstructured objects are served by a fake dynamic client, their kinds have to be known to the fake REST mapper.
+//   fake := client.NewFake(&pod, &instance)
+//   fake.FailOn("delete", "pods", errors.New("forbidden"))
+//   fake.ProgressPlan("default", "kafka", "deploy", kudov1beta1.ExecutionInProgress, kudov1beta1.ExecutionComplete)
+//   err := helperUnderTest(fake.Client)
+type Fake struct {
+	Client
+
+	FakeKubernetes *kubernetesfake.Clientset
+	FakeKudo       *kudofake.Clientset
+	FakeDynamic    *dynamicfake.FakeDynamicClient
+	FakeRESTMapper *meta.DefaultRESTMapper
+	Executor       *cmd.FakeExecutor
+
+	mutex sync.Mutex
+	logs  map[string][]byte
+}
+
+// NewFake creates a Fake with the given objects.
+// KUDO objects are added to the fake KUDO clientset and unstructured objects to the fake dynamic client,
+// all other objects to the fake Kubernetes clientset.
+// The kinds of unstructured objects are added to the fake REST mapper, as namespaced kinds if the object
+// has a namespace.
+func NewFake(objects ...runtime.Object) *Fake {
+	var kubernetesObjects, kudoObjects, dynamicObjects []runtime.Object
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+
+	for _, object := range objects {
+		if u, ok := object.(*unstructured.Unstructured); ok {
+			dynamicObjects = append(dynamicObjects, object)
+
+			addKind(restMapper, u.GroupVersionKind(), u.GetNamespace() != "")
+
+			continue
+		}
+
+		if _, _, err := kudoscheme.Scheme.ObjectKinds(object); err == nil {
+			kudoObjects = append(kudoObjects, object)
+		} else {
+			kubernetesObjects = append(kubernetesObjects, object)
+		}
+	}
+
+	fake := &Fake{
+		FakeKubernetes: kubernetesfake.NewSimpleClientset(kubernetesObjects...),
+		FakeKudo:       kudofake.NewSimpleClientset(kudoObjects...),
+		FakeDynamic:    dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), dynamicObjects...),
+		FakeRESTMapper: restMapper,
+		Executor:       cmd.NewFakeExecutor(),
+		logs:           make(map[string][]byte),
+	}
+
+	fake.Client = Client{
+		Ctx:        context.TODO(),
+		Kubernetes: fake.FakeKubernetes,
+		Kudo:       fake.FakeKudo,
+		Dynamic:    fake.FakeDynamic,
+		RESTMapper: fake.FakeRESTMapper,
+		PodStreams: fakePodStreams{fake: fake},
+	}
+
+	return fake
+}
+
+// WithKind adds a kind to the fake REST mapper.
+// The resource of the kind is its lower-case plural.
+func (fake *Fake) WithKind(gvk schema.GroupVersionKind, namespaced bool) *Fake {
+	addKind(fake.FakeRESTMapper, gvk, namespaced)
+
+	return fake
+}
+
+func addKind(restMapper *meta.DefaultRESTMapper, gvk schema.GroupVersionKind, namespaced bool) {
+	scope := meta.RESTScopeRoot
+	if namespaced {
+		scope = meta.RESTScopeNamespace
+	}
+
+	restMapper.Add(gvk, scope)
+}
+
+// FailOn makes all requests with a verb on a resource fail with an error.
+// A resource of "*" matches all resources.
+func (fake *Fake) FailOn(verb string, resource string, err error) {
+	reaction := func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, err
+	}
+
+	fake.FakeKubernetes.PrependReactor(verb, resource, reaction)
+	fake.FakeKudo.PrependReactor(verb, resource, reaction)
+	fake.FakeDynamic.PrependReactor(verb, resource, reaction)
+}
+
+// WithContainerLogs sets the logs returned for a pod's container.
+func (fake *Fake) WithContainerLogs(namespace string, pod string, container string, logs []byte) *Fake {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.logs[containerKey(namespace, pod, container)] = logs
+
+	return fake
+}
+
+// WithPreviousContainerLogs sets the logs returned for the previous instance of a pod's container.
+func (fake *Fake) WithPreviousContainerLogs(namespace string, pod string, container string, logs []byte) *Fake {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.logs[containerKey(namespace, pod, container)+"/previous"] = logs
+
+	return fake
+}
+
+// ProgressPlan simulates the KUDO controller working on a plan of an instance.
+// Every time the instance is retrieved, its plan status advances to the next of the given statuses,
+// remaining at the last one.
+func (fake *Fake) ProgressPlan(
+	namespace string,
+	instance string,
+	plan string,
+	statuses ...kudov1beta1.ExecutionStatus) {
+	var mutex sync.Mutex
+
+	next := 0
+	uid := apimachinerytypes.UID(fmt.Sprintf("%s-%s-%s", namespace, instance, plan))
+
+	fake.FakeKudo.PrependReactor("get", "instances", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get, ok := action.(k8stesting.GetAction)
+		if !ok || get.GetNamespace() != namespace || get.GetName() != instance || len(statuses) == 0 {
+			return false, nil, nil
+		}
+
+		object, err := fake.FakeKudo.Tracker().Get(get.GetResource(), namespace, instance)
+		if err != nil {
+			return true, nil, err
+		}
+
+		current, ok := object.(*kudov1beta1.Instance)
+		if !ok {
+			return false, nil, nil
+		}
+
+		mutex.Lock()
+		status := statuses[next]
+
+		if next < len(statuses)-1 {
+			next++
+		}
+		mutex.Unlock()
+
+		current = current.DeepCopy()
+
+		if current.Status.PlanStatus == nil {
+			current.Status.PlanStatus = make(map[string]kudov1beta1.PlanStatus)
+		}
+
+		current.Status.PlanStatus[plan] = kudov1beta1.PlanStatus{
+			Name:   plan,
+			Status: status,
+			UID:    uid,
+		}
+
+		if err := fake.FakeKudo.Tracker().Update(get.GetResource(), current, namespace); err != nil {
+			return true, nil, err
+		}
+
+		return true, current, nil
+	})
+}
+
+func containerKey(namespace string, pod string, container string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, pod, container)
+}
+
+// fakePodStreams runs commands in containers with the FakeExecutor of a Fake.
+type fakePodStreams struct {
+	fake *Fake
+}
+
+func (streams fakePodStreams) Exec(
+	namespace string,
+	name string,
+	options corev1.PodExecOptions) (remotecommand.Executor, error) {
+	if len(options.Command) == 0 {
+		return nil, fmt.Errorf("no command to execute in pod %s in namespace %s", name, namespace)
+	}
+
+	return fakeStream{
+		executor: streams.fake.Executor,
+		command:  cmd.New(options.Command[0]).WithArguments(options.Command[1:]...),
+	}, nil
+}
+
+func (streams fakePodStreams) Logs(namespace string, name string, options corev1.PodLogOptions) ([]byte, error) {
+	streams.fake.mutex.Lock()
+	defer streams.fake.mutex.Unlock()
+
+	key := containerKey(namespace, name, options.Container)
+	if options.Previous {
+		key += "/previous"
+	}
+
+	logs, ok := streams.fake.logs[key]
+	if !ok {
+		return nil, fmt.Errorf("no logs for container %s of pod %s in namespace %s", options.Container, name, namespace)
+	}
+
+	if options.TailLines != nil {
+		lines := bytes.SplitAfter(logs, []byte("\n"))
+		if len(lines[len(lines)-1]) == 0 {
+			lines = lines[:len(lines)-1]
+		}
+
+		if int64(len(lines)) > *options.TailLines {
+			logs = bytes.Join(lines[int64(len(lines))-*options.TailLines:], nil)
+		}
+	}
+
+	return logs, nil
+}
+
+type fakeStream struct {
+	executor *cmd.FakeExecutor
+	command  cmd.Builder
+}
+
+func (stream fakeStream) Stream(options remotecommand.StreamOptions) error {
+	command := stream.command.
+		WithStdin(options.Stdin).
+		WithStdout(options.Stdout).
+		WithStderr(options.Stderr)
+
+	result, err := stream.executor.Execute(context.TODO(), command)
+	if err != nil {
+		return err
+	}
+
+	if result.ExitCode != 0 {
+		return utilexec.CodeExitError{
+			Err:  fmt.Errorf("command terminated with exit code %d", result.ExitCode),
+			Code: result.ExitCode,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewFake(t *testing.T) {
+	const namespace = "test"
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+		},
+	}
+
+	instance := kudov1beta1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: namespace,
+		},
+	}
+
+	fake := NewFake(&pod, &instance)
+
+	_, err := fake.Kubernetes.CoreV1().Pods(namespace).Get(fake.Ctx, pod.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	_, err = fake.Kudo.KudoV1beta1().Instances(namespace).Get(fake.Ctx, instance.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	fake.FailOn("get", "pods", errors.New("forbidden"))
+
+	_, err = fake.Kubernetes.CoreV1().Pods(namespace).Get(fake.Ctx, pod.Name, metav1.GetOptions{})
+	assert.EqualError(t, err, "forbidden")
+}
+
+func TestFakeProgressPlan(t *testing.T) {
+	const namespace = "test"
+
+	instance := kudov1beta1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: namespace,
+		},
+	}
+
+	fake := NewFake(&instance)
+	fake.ProgressPlan(namespace, instance.Name, "deploy", kudov1beta1.ExecutionInProgress, kudov1beta1.ExecutionComplete)
+
+	expected := []kudov1beta1.ExecutionStatus{
+		kudov1beta1.ExecutionInProgress,
+		kudov1beta1.ExecutionComplete,
+		kudov1beta1.ExecutionComplete,
+	}
+
+	for _, status := range expected {
+		current, err := fake.Kudo.KudoV1beta1().Instances(namespace).Get(fake.Ctx, instance.Name, metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, status, current.Status.PlanStatus["deploy"].Status)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// Options is used to configure the creation of a Client.
+type Options struct {
+	Ctx         context.Context
+	KubeContext string
+	QPS         float32
+	Burst       int
+	Timeout     time.Duration
+	Impersonate rest.ImpersonationConfig
+}
+
+// Option changes Options.
+type Option func(*Options)
+
+func newOptions(options []Option) Options {
+	result := Options{
+		Ctx: context.TODO(),
+	}
+
+	for _, option := range options {
+		option(&result)
+	}
+
+	return result
+}
+
+// ParentContext sets the context used for all requests of a client.
+func ParentContext(ctx context.Context) Option {
+	return func(options *Options) {
+		options.Ctx = ctx
+	}
+}
+
+// KubeContext selects a context of the kubeconfig instead of its current context.
+func KubeContext(name string) Option {
+	return func(options *Options) {
+		options.KubeContext = name
+	}
+}
+
+// RateLimit sets the maximum queries per second and the burst of a client.
+func RateLimit(qps float32, burst int) Option {
+	return func(options *Options) {
+		options.QPS = qps
+		options.Burst = burst
+	}
+}
+
+// RequestTimeout sets the timeout of each request of a client.
+func RequestTimeout(timeout time.Duration) Option {
+	return func(options *Options) {
+		options.Timeout = timeout
+	}
+}
+
+// Impersonate makes a client act as another user.
+func Impersonate(user string, groups ...string) Option {
+	return func(options *Options) {
+		options.Impersonate = rest.ImpersonationConfig{
+			UserName: user,
+			Groups:   groups,
+		}
+	}
+}
+
+// ImpersonateServiceAccount makes a client act as a service account.
+func ImpersonateServiceAccount(namespace string, name string) Option {
+	return Impersonate(
+		fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+		"system:serviceaccounts",
+		fmt.Sprintf("system:serviceaccounts:%s", namespace))
+}
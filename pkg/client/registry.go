@@ -0,0 +1,128 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Registry holds the clients of multiple named clusters.
+// Helpers can be run against each cluster of a registry, e.g. in disaster-recovery tests spanning two clusters.
+//   registry, err := client.NewRegistryForContexts(kubeconfigPath, []string{"primary", "secondary"})
+//   if err != nil ...
+//   err = registry.ForEach(func(cluster string, c client.Client) error {
+//   	return kubernetes.CreateNamespace(c, "kafka")
+//   })
+type Registry struct {
+	names   []string
+	clients map[string]Client
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		clients: make(map[string]Client),
+	}
+}
+
+// NewRegistryForContexts creates a Registry with a client for contexts of a kubeconfig.
+// Clusters are named after their context. If no contexts are given, all contexts of the kubeconfig are used.
+func NewRegistryForContexts(kubeconfigPath string, contexts []string, options ...Option) (*Registry, error) {
+	if len(contexts) == 0 {
+		kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubeconfig %s: %w", kubeconfigPath, err)
+		}
+
+		for context := range kubeconfig.Contexts {
+			contexts = append(contexts, context)
+		}
+
+		sort.Strings(contexts)
+	}
+
+	registry := NewRegistry()
+
+	for _, context := range contexts {
+		client, err := NewForConfig(kubeconfigPath, append(append([]Option{}, options...), KubeContext(context))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for context %s: %w", context, err)
+		}
+
+		if err := registry.Add(context, client); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// NewRegistryForConfigs creates a Registry with a client for each kubeconfig, using their current context.
+// The kubeconfig paths are keyed by the name of their cluster.
+func NewRegistryForConfigs(kubeconfigPaths map[string]string, options ...Option) (*Registry, error) {
+	names := make([]string, 0, len(kubeconfigPaths))
+
+	for name := range kubeconfigPaths {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	registry := NewRegistry()
+
+	for _, name := range names {
+		client, err := NewForConfig(kubeconfigPaths[name], options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for cluster %s: %w", name, err)
+		}
+
+		if err := registry.Add(name, client); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Add adds the client of a named cluster.
+func (registry *Registry) Add(name string, client Client) error {
+	if _, ok := registry.clients[name]; ok {
+		return fmt.Errorf("cluster %s is already registered", name)
+	}
+
+	registry.names = append(registry.names, name)
+	registry.clients[name] = client
+
+	return nil
+}
+
+// Names returns the names of all clusters in the order they were added.
+func (registry *Registry) Names() []string {
+	return append([]string{}, registry.names...)
+}
+
+// Client returns the client of a named cluster.
+func (registry *Registry) Client(name string) (Client, error) {
+	client, ok := registry.clients[name]
+	if !ok {
+		return Client{}, fmt.Errorf("cluster %s is not registered", name)
+	}
+
+	return client, nil
+}
+
+// ForEach runs a function for each cluster in the order they were added.
+// It continues if the function fails for a cluster and returns the errors of all clusters.
+func (registry *Registry) ForEach(f func(name string, client Client) error) error {
+	var errs []error
+
+	for _, name := range registry.names {
+		if err := f(name, registry.clients[name]); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %s: %w", name, err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
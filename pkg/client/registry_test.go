@@ -0,0 +1,68 @@
+package client
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryForContexts(t *testing.T) {
+	directory, err := ioutil.TempDir("", "client")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer os.RemoveAll(directory)
+
+	kubeconfigPath := path.Join(directory, "kubeconfig")
+	assert.NoError(t, ioutil.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600))
+
+	registry, err := NewRegistryForContexts(kubeconfigPath, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, registry.Names())
+
+	second, err := registry.Client("second")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://second.example.com", second.Config.Host)
+
+	// Options with spare capacity must not be overwritten by the context of each client.
+	options := make([]Option, 1, 2)
+	options[0] = RateLimit(10, 20)
+
+	_, err = NewRegistryForContexts(kubeconfigPath, nil, options...)
+	assert.NoError(t, err)
+	assert.Nil(t, options[:2][1])
+
+	registry, err = NewRegistryForConfigs(map[string]string{"primary": kubeconfigPath})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"primary"}, registry.Names())
+
+	_, err = registry.Client("secondary")
+	assert.EqualError(t, err, "cluster secondary is not registered")
+}
+
+func TestRegistryForEach(t *testing.T) {
+	registry := NewRegistry()
+
+	assert.NoError(t, registry.Add("primary", Client{KubeConfigPath: "primary"}))
+	assert.NoError(t, registry.Add("secondary", Client{KubeConfigPath: "secondary"}))
+	assert.EqualError(t, registry.Add("primary", Client{}), "cluster primary is already registered")
+
+	var visited []string
+
+	err := registry.ForEach(func(name string, client Client) error {
+		visited = append(visited, client.KubeConfigPath)
+
+		if name == "primary" {
+			return errors.New("unreachable")
+		}
+
+		return nil
+	})
+	assert.EqualError(t, err, "cluster primary: unreachable")
+	assert.Equal(t, []string{"primary", "secondary"}, visited)
+}
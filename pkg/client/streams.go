@@ -0,0 +1,14 @@
+package client
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// PodStreams replaces the streaming subresources of pods that aren't available through fake clientsets.
+type PodStreams interface {
+	// Exec creates an executor for a command in a pod's container.
+	Exec(namespace string, name string, options corev1.PodExecOptions) (remotecommand.Executor, error)
+	// Logs returns the logs of a pod's container.
+	Logs(namespace string, name string, options corev1.PodLogOptions) ([]byte, error)
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Tracker records the resources created through a Client, so that they can be cleaned up after a test.
+// Resources are cleaned up in the reverse order of their creation.
+//   tracker := client.NewTracker().WithKeepOnFailure()
+//   c = c.WithTracker(tracker)
+//   tracker.CleanupAfter(t)
+// With Ginkgo, resources can be cleaned up after each test:
+//   AfterEach(func() {
+//   	Expect(tracker.Finish(CurrentGinkgoTestDescription().Failed)).To(Succeed())
+//   })
+type Tracker struct {
+	keepOnFailure bool
+
+	mutex     sync.Mutex
+	resources []trackedResource
+}
+
+type trackedResource struct {
+	description string
+	cleanup     func() error
+}
+
+// TestingT is the subset of testing.TB used to clean up after a test.
+type TestingT interface {
+	Cleanup(func())
+	Failed() bool
+	Errorf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+// CleanupError is the error returned when some tracked resources couldn't be cleaned up.
+type CleanupError struct {
+	Errors []error
+}
+
+// Error returns a pretty-printed error string.
+func (c CleanupError) Error() string {
+	messages := make([]string, 0, len(c.Errors))
+
+	for _, err := range c.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("failed to clean up %d resources: %s", len(c.Errors), strings.Join(messages, "; "))
+}
+
+// NewTracker creates a Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// WithKeepOnFailure keeps the tracked resources of a failed test for debugging.
+func (tracker *Tracker) WithKeepOnFailure() *Tracker {
+	tracker.keepOnFailure = true
+
+	return tracker
+}
+
+// WithTracker returns a Client that records all resources created with it in a Tracker.
+func (client Client) WithTracker(tracker *Tracker) Client {
+	client.Tracker = tracker
+
+	return client
+}
+
+// Track records a function to clean up a created resource, if the client has a Tracker.
+func (client Client) Track(description string, cleanup func() error) {
+	if client.Tracker == nil {
+		return
+	}
+
+	client.Tracker.Track(description, cleanup)
+}
+
+// Track records a function to clean up a created resource.
+func (tracker *Tracker) Track(description string, cleanup func() error) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.resources = append(tracker.resources, trackedResource{
+		description: description,
+		cleanup:     cleanup,
+	})
+}
+
+// Tracked returns the descriptions of all tracked resources in the order of their creation.
+func (tracker *Tracker) Tracked() []string {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	descriptions := make([]string, 0, len(tracker.resources))
+
+	for _, resource := range tracker.resources {
+		descriptions = append(descriptions, resource.description)
+	}
+
+	return descriptions
+}
+
+// Cleanup cleans up all tracked resources in the reverse order of their creation.
+// Resources that have already been deleted are ignored. Cleanup continues if a resource
+// can't be cleaned up and returns a CleanupError listing all failures.
+func (tracker *Tracker) Cleanup() error {
+	tracker.mutex.Lock()
+	resources := tracker.resources
+	tracker.resources = nil
+	tracker.mutex.Unlock()
+
+	var errs []error
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		err := resources[i].cleanup()
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("%s: %w", resources[i].description, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return CleanupError{Errors: errs}
+	}
+
+	return nil
+}
+
+// Finish cleans up all tracked resources at the end of a test.
+// If the test failed and the tracker keeps resources on failure, the resources are
+// kept and no longer tracked.
+func (tracker *Tracker) Finish(failed bool) error {
+	if failed && tracker.keepOnFailure {
+		tracker.mutex.Lock()
+		tracker.resources = nil
+		tracker.mutex.Unlock()
+
+		return nil
+	}
+
+	return tracker.Cleanup()
+}
+
+// CleanupAfter cleans up all tracked resources once a test and all its subtests completed.
+func (tracker *Tracker) CleanupAfter(t TestingT) {
+	t.Cleanup(func() {
+		if t.Failed() && tracker.keepOnFailure {
+			t.Logf("keeping resources of failed test: %s", strings.Join(tracker.Tracked(), ", "))
+		}
+
+		if err := tracker.Finish(t.Failed()); err != nil {
+			t.Errorf("%v", err)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	client := Client{}.WithTracker(tracker)
+
+	var cleaned []string
+
+	cleanup := func(name string, err error) func() error {
+		return func() error {
+			cleaned = append(cleaned, name)
+			return err
+		}
+	}
+
+	client.Track("namespace", cleanup("namespace", nil))
+	client.Track("secret", cleanup("secret", errors.New("forbidden")))
+	client.Track("pod", cleanup("pod", apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "pod")))
+
+	assert.Equal(t, []string{"namespace", "secret", "pod"}, tracker.Tracked())
+
+	err := tracker.Cleanup()
+	assert.EqualError(t, err, "failed to clean up 1 resources: secret: forbidden")
+	assert.Equal(t, []string{"pod", "secret", "namespace"}, cleaned)
+	assert.Empty(t, tracker.Tracked())
+}
+
+func TestTrackerKeepOnFailure(t *testing.T) {
+	tracker := NewTracker().WithKeepOnFailure()
+
+	cleaned := false
+
+	tracker.Track("namespace", func() error {
+		cleaned = true
+		return nil
+	})
+
+	assert.NoError(t, tracker.Finish(true))
+	assert.False(t, cleaned)
+	assert.Empty(t, tracker.Tracked())
+}
+
+func TestUntrackedClient(t *testing.T) {
+	Client{}.Track("namespace", func() error {
+		assert.Fail(t, "untracked resources aren't cleaned up")
+		return nil
+	})
+}
@@ -2,21 +2,35 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"sync"
+	"time"
 )
 
 // Builder tracks the options set for a command.
 type Builder struct {
-	Command     string
-	Arguments   []string
-	Environment []string
-	Stdin       io.Reader
-	Stdout      io.Writer
-	Stderr      io.Writer
+	Command          string
+	Arguments        []string
+	Environment      []string
+	CleanEnvironment bool
+	Directory        string
+	Stdin            io.Reader
+	Stdout           io.Writer
+	Stderr           io.Writer
+}
+
+// Result holds the captured output, exit code and duration of a command.
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+	Duration time.Duration
 }
 
 // New creates a new command.
@@ -40,13 +54,64 @@ func (builder Builder) Run() error {
 }
 
 // RunWithContext runs a command with a context.
+// The command is killed if the context is done before the command completes.
 func (builder Builder) RunWithContext(ctx context.Context) error {
-	cmd := exec.Command(builder.Command, builder.Arguments...) //nolint:gosec
+	cmd := exec.CommandContext(ctx, builder.Command, builder.Arguments...) //nolint:gosec
 	builder.prepareCmd(cmd)
 
 	return cmd.Run()
 }
 
+// RunWithResult runs a command with a context and captures its output, exit code and duration.
+// Writers set in the builder still receive the output while it is captured.
+// A command exiting with a non-zero exit code returns an *exec.ExitError along with the result.
+func (builder Builder) RunWithResult(ctx context.Context) (Result, error) {
+	var stdout, stderr bytes.Buffer
+
+	start := time.Now()
+
+	err := builder.
+		WithStdout(CaptureWriter(&stdout, builder.Stdout)).
+		WithStderr(CaptureWriter(&stderr, builder.Stderr)).
+		RunWithContext(ctx)
+
+	result := Result{
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		Duration: time.Since(start),
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+	}
+
+	return result, err
+}
+
+// Output runs a command and returns its output.
+func (builder Builder) Output() ([]byte, error) {
+	var stdout bytes.Buffer
+
+	err := builder.
+		WithStdout(CaptureWriter(&stdout, builder.Stdout)).
+		Run()
+
+	return stdout.Bytes(), err
+}
+
+// CombinedOutput runs a command and returns its combined output and error output.
+func (builder Builder) CombinedOutput() ([]byte, error) {
+	var output synchronizedBuffer
+
+	err := builder.
+		WithStdout(CaptureWriter(&output, builder.Stdout)).
+		WithStderr(CaptureWriter(&output, builder.Stderr)).
+		Run()
+
+	return output.Bytes(), err
+}
+
 // WithArguments adds arguments to a command.
 func (builder Builder) WithArguments(arguments ...string) Builder {
 	builder.Arguments = arguments
@@ -64,6 +129,19 @@ func (builder Builder) WithEnvironment(environment map[string]string) Builder {
 	return builder
 }
 
+// WithCleanEnvironment runs a command without inheriting the environment of the current process.
+// Only environment variables added with WithEnvironment are set.
+func (builder Builder) WithCleanEnvironment() Builder {
+	builder.CleanEnvironment = true
+	return builder
+}
+
+// WithDirectory sets the working directory of a command.
+func (builder Builder) WithDirectory(directory string) Builder {
+	builder.Directory = directory
+	return builder
+}
+
 // WithStdin sets an io.Reader to use as input to the command.
 func (builder Builder) WithStdin(stdin io.Reader) Builder {
 	builder.Stdin = stdin
@@ -83,7 +161,13 @@ func (builder Builder) WithStderr(stderr io.Writer) Builder {
 }
 
 func (builder Builder) prepareCmd(cmd *exec.Cmd) {
-	cmd.Env = append(os.Environ(), builder.Environment...)
+	if builder.CleanEnvironment {
+		cmd.Env = append([]string{}, builder.Environment...)
+	} else {
+		cmd.Env = append(os.Environ(), builder.Environment...)
+	}
+
+	cmd.Dir = builder.Directory
 
 	if builder.Stdin != nil {
 		cmd.Stdin = builder.Stdin
@@ -97,3 +181,32 @@ func (builder Builder) prepareCmd(cmd *exec.Cmd) {
 		cmd.Stderr = builder.Stderr
 	}
 }
+
+// CaptureWriter returns a writer capturing output in a buffer while still passing it to a writer, if one is set.
+func CaptureWriter(buffer io.Writer, writer io.Writer) io.Writer {
+	if writer == nil {
+		return buffer
+	}
+
+	return io.MultiWriter(buffer, writer)
+}
+
+// synchronizedBuffer is a bytes.Buffer that can be written to concurrently.
+type synchronizedBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (b *synchronizedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *synchronizedBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Bytes()
+}
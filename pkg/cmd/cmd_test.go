@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,3 +24,80 @@ func TestArguments(t *testing.T) {
 
 	assert.Equal(t, test, stdout.String())
 }
+
+func TestRunWithContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond*10)
+	defer cancel()
+
+	start := time.Now()
+
+	err := New("sleep").
+		WithArguments("10").
+		RunWithContext(ctx)
+	assert.Error(t, err)
+
+	assert.True(t, time.Since(start) < time.Second*10)
+}
+
+func TestRunWithResult(t *testing.T) {
+	var stdout strings.Builder
+
+	result, err := New("sh").
+		WithArguments("-c", "echo -n out; echo -n err >&2; exit 3").
+		WithStdout(&stdout).
+		RunWithResult(context.TODO())
+	assert.Error(t, err)
+
+	assert.Equal(t, "out", string(result.Stdout))
+	assert.Equal(t, "err", string(result.Stderr))
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "out", stdout.String())
+}
+
+func TestOutput(t *testing.T) {
+	output, err := New("sh").
+		WithArguments("-c", "echo -n out; echo -n err >&2").
+		Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "out", string(output))
+
+	output, err = New("sh").
+		WithArguments("-c", "echo -n out; echo -n err >&2").
+		CombinedOutput()
+	assert.NoError(t, err)
+	assert.Equal(t, "outerr", string(output))
+}
+
+func TestEnvironment(t *testing.T) {
+	assert.NoError(t, os.Setenv("TEST_INHERITED", "inherited"))
+
+	defer os.Unsetenv("TEST_INHERITED")
+
+	output, err := New("sh").
+		WithArguments("-c", "echo -n $TEST_INHERITED $TEST_SET").
+		WithEnvironment(map[string]string{"TEST_SET": "set"}).
+		Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "inherited set", string(output))
+
+	output, err = New("/bin/sh").
+		WithArguments("-c", "echo -n $TEST_INHERITED $TEST_SET").
+		WithEnvironment(map[string]string{"TEST_SET": "set"}).
+		WithCleanEnvironment().
+		Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "set", string(output))
+}
+
+func TestDirectory(t *testing.T) {
+	directory, err := ioutil.TempDir("", "cmd")
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(directory)
+
+	output, err := New("pwd").
+		WithDirectory(directory).
+		Output()
+	assert.NoError(t, err)
+	assert.Equal(t, directory, strings.TrimSpace(string(output)))
+}
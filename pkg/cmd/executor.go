@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Executor runs commands.
+// Helpers that run commands through an Executor can be used with local commands as well as with
+// commands in a Kubernetes container.
+type Executor interface {
+	Execute(ctx context.Context, command Builder) (Result, error)
+}
+
+// ExitError is the error returned by an Executor when a command exits with a non-zero exit code.
+// Errors of all executors can be handled the same way:
+//   var exitErr cmd.ExitError
+//   if errors.As(err, &exitErr) && exitErr.ExitCode == 1 ...
+type ExitError struct {
+	Command  string
+	ExitCode int
+	Stderr   []byte
+}
+
+// Error returns a pretty-printed error string.
+func (e ExitError) Error() string {
+	return fmt.Sprintf("command \"%s\" exited with code %d: %s", e.Command, e.ExitCode, e.Stderr)
+}
+
+// ExitStatus returns the exit code of the command.
+func (e ExitError) ExitStatus() int { return e.ExitCode }
+
+// LocalExecutor runs commands on the local host.
+type LocalExecutor struct{}
+
+// Execute runs a command on the local host.
+// A command exiting with a non-zero exit code returns an ExitError along with the result.
+func (LocalExecutor) Execute(ctx context.Context, command Builder) (Result, error) {
+	result, err := command.RunWithResult(ctx)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil {
+		return result, ExitError{
+			Command:  strings.Join(append([]string{command.Command}, command.Arguments...), " "),
+			ExitCode: result.ExitCode,
+			Stderr:   result.Stderr,
+		}
+	}
+
+	return result, err
+}
+
+// FakeReaction scripts the result of a command run by a FakeExecutor.
+// A reaction that doesn't handle a command returns false.
+type FakeReaction func(command Builder) (handled bool, result Result, err error)
+
+// FakeExecutor is an Executor for unit tests.
+// It records all commands and returns the results of scripted reactions.
+//   executor := cmd.NewFakeExecutor().
+//   	WithCommandResult("kubectl", cmd.Result{Stdout: []byte("v1.19.3")}, nil)
+type FakeExecutor struct {
+	mutex       sync.Mutex
+	reactions   []FakeReaction
+	invocations []Builder
+}
+
+// NewFakeExecutor creates a FakeExecutor without any reactions.
+func NewFakeExecutor() *FakeExecutor {
+	return &FakeExecutor{}
+}
+
+// WithReaction adds a reaction to the executor.
+// Reactions are tried in the order they were added.
+func (executor *FakeExecutor) WithReaction(reaction FakeReaction) *FakeExecutor {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	executor.reactions = append(executor.reactions, reaction)
+
+	return executor
+}
+
+// WithCommandResult adds a reaction returning a result for all invocations of a command.
+func (executor *FakeExecutor) WithCommandResult(command string, result Result, err error) *FakeExecutor {
+	return executor.WithReaction(func(builder Builder) (bool, Result, error) {
+		return builder.Command == command, result, err
+	})
+}
+
+// Invocations returns all commands the executor has been called with.
+func (executor *FakeExecutor) Invocations() []Builder {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	return append([]Builder{}, executor.invocations...)
+}
+
+// Execute records a command and returns the result of the first reaction handling it.
+// The output of the result is written to the writers set in the command.
+func (executor *FakeExecutor) Execute(ctx context.Context, command Builder) (Result, error) {
+	executor.mutex.Lock()
+	executor.invocations = append(executor.invocations, command)
+	reactions := executor.reactions
+	executor.mutex.Unlock()
+
+	for _, reaction := range reactions {
+		handled, result, err := reaction(command)
+		if !handled {
+			continue
+		}
+
+		if command.Stdout != nil {
+			if _, writeErr := command.Stdout.Write(result.Stdout); writeErr != nil {
+				return result, writeErr
+			}
+		}
+
+		if command.Stderr != nil {
+			if _, writeErr := command.Stderr.Write(result.Stderr); writeErr != nil {
+				return result, writeErr
+			}
+		}
+
+		return result, err
+	}
+
+	return Result{}, fmt.Errorf("fake executor has no reaction for command \"%s\"", command.Command)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalExecutor(t *testing.T) {
+	var executor Executor = LocalExecutor{}
+
+	result, err := executor.Execute(context.TODO(), New("echo").WithArguments("-n", "local"))
+	assert.NoError(t, err)
+	assert.Equal(t, "local", string(result.Stdout))
+
+	result, err = executor.Execute(context.TODO(), New("sh").WithArguments("-c", "echo -n failed >&2; exit 3"))
+
+	var exitErr ExitError
+	if assert.True(t, errors.As(err, &exitErr)) {
+		assert.Equal(t, 3, exitErr.ExitStatus())
+		assert.Equal(t, "failed", string(exitErr.Stderr))
+		assert.Equal(t, "sh -c echo -n failed >&2; exit 3", exitErr.Command)
+	}
+
+	assert.Equal(t, 3, result.ExitCode)
+}
+
+func TestFakeExecutor(t *testing.T) {
+	failure := errors.New("failure")
+
+	executor := NewFakeExecutor().
+		WithReaction(func(command Builder) (bool, Result, error) {
+			return command.Command == "false", Result{ExitCode: 1}, failure
+		}).
+		WithCommandResult("echo", Result{Stdout: []byte("fake")}, nil)
+
+	var stdout strings.Builder
+
+	result, err := executor.Execute(context.TODO(), New("echo").WithArguments("local").WithStdout(&stdout))
+	assert.NoError(t, err)
+	assert.Equal(t, "fake", string(result.Stdout))
+	assert.Equal(t, "fake", stdout.String())
+
+	result, err = executor.Execute(context.TODO(), New("false"))
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 1, result.ExitCode)
+
+	_, err = executor.Execute(context.TODO(), New("true"))
+	assert.EqualError(t, err, "fake executor has no reaction for command \"true\"")
+
+	invocations := executor.Invocations()
+	if assert.Len(t, invocations, 3) {
+		assert.Equal(t, []string{"local"}, invocations[0].Arguments)
+		assert.Equal(t, "false", invocations[1].Command)
+		assert.Equal(t, "true", invocations[2].Command)
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const pollInterval = time.Millisecond * 100
+
+// Process is a command running in the background.
+type Process struct {
+	cmd    *exec.Cmd
+	output *outputWatcher
+	done   chan struct{}
+	err    error
+}
+
+// Start starts a command in the background.
+// The command and the processes it spawns run in their own process group, which is terminated by Stop.
+// Writers set in the builder receive the output while the command is running.
+//   process, err := cmd.New("kubectl").
+//   	WithArguments("proxy", "--port", "8001").
+//   	Start()
+//   if err != nil ...
+//   defer process.Stop(time.Second * 5)
+//   err = process.WaitForPort(ctx, "localhost:8001")
+func (builder Builder) Start() (*Process, error) {
+	output := newOutputWatcher()
+
+	cmd := exec.Command(builder.Command, builder.Arguments...) //nolint:gosec
+	builder.
+		WithStdout(CaptureWriter(output, builder.Stdout)).
+		WithStderr(CaptureWriter(output, builder.Stderr)).
+		prepareCmd(cmd)
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start \"%s\": %w", builder.Command, err)
+	}
+
+	process := &Process{
+		cmd:    cmd,
+		output: output,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		process.err = cmd.Wait()
+
+		close(process.done)
+	}()
+
+	return process, nil
+}
+
+// Pid returns the process ID of the command.
+func (process *Process) Pid() int {
+	return process.cmd.Process.Pid
+}
+
+// Done returns a channel that is closed once the command has exited.
+func (process *Process) Done() <-chan struct{} {
+	return process.done
+}
+
+// Wait waits for the command to exit.
+func (process *Process) Wait() error {
+	<-process.done
+
+	return process.err
+}
+
+// Output returns the output and error output the command produced so far.
+func (process *Process) Output() []byte {
+	output, _ := process.output.snapshot()
+
+	return output
+}
+
+// Stop terminates the process group of the command.
+// The processes are sent a termination signal and are killed if they are still running after the grace period.
+func (process *Process) Stop(gracePeriod time.Duration) error {
+	if err := terminateProcessGroup(process.cmd); err != nil {
+		return fmt.Errorf("failed to terminate process %d: %w", process.Pid(), err)
+	}
+
+	deadline := time.NewTimer(gracePeriod)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for processGroupAlive(process.cmd, process.done) {
+		select {
+		case <-deadline.C:
+			if err := killProcessGroup(process.cmd); err != nil {
+				return fmt.Errorf("failed to kill process %d: %w", process.Pid(), err)
+			}
+
+			<-process.done
+
+			return nil
+		case <-ticker.C:
+		}
+	}
+
+	<-process.done
+
+	return nil
+}
+
+// WaitForOutput waits until the output or error output of the command matches a regular expression.
+func (process *Process) WaitForOutput(ctx context.Context, pattern *regexp.Regexp) error {
+	for {
+		output, changed := process.output.snapshot()
+		if pattern.Match(output) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("output of process %d didn't match %s: %w", process.Pid(), pattern, ctx.Err())
+		case <-process.done:
+			output, _ := process.output.snapshot()
+			if pattern.Match(output) {
+				return nil
+			}
+
+			return fmt.Errorf("process %d exited before its output matched %s", process.Pid(), pattern)
+		case <-changed:
+		}
+	}
+}
+
+// WaitForPort waits until a TCP connection to an address can be established.
+func (process *Process) WaitForPort(ctx context.Context, address string) error {
+	var dialer net.Dialer
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to connect to %s: %w", address, ctx.Err())
+		case <-process.done:
+			return fmt.Errorf("process %d exited before %s accepted connections", process.Pid(), address)
+		case <-ticker.C:
+		}
+	}
+}
+
+// outputWatcher captures output and notifies waiters about new output.
+type outputWatcher struct {
+	mutex   sync.Mutex
+	buffer  bytes.Buffer
+	changed chan struct{}
+}
+
+func newOutputWatcher() *outputWatcher {
+	return &outputWatcher{
+		changed: make(chan struct{}),
+	}
+}
+
+func (w *outputWatcher) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	n, err := w.buffer.Write(p)
+
+	close(w.changed)
+	w.changed = make(chan struct{})
+
+	return n, err
+}
+
+// snapshot returns the current output and a channel that is closed once there is more output.
+func (w *outputWatcher) snapshot() ([]byte, <-chan struct{}) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return append([]byte{}, w.buffer.Bytes()...), w.changed
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcess(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*10)
+	defer cancel()
+
+	process, err := New("sh").
+		WithArguments("-c", "echo starting; sleep 1; echo ready; sleep 30").
+		Start()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = process.WaitForOutput(ctx, regexp.MustCompile("ready"))
+	assert.NoError(t, err)
+	assert.Equal(t, "starting\nready\n", string(process.Output()))
+
+	err = process.Stop(time.Second * 5)
+	assert.NoError(t, err)
+
+	assert.Error(t, process.Wait())
+}
+
+func TestProcessExited(t *testing.T) {
+	process, err := New("echo").
+		WithArguments("done").
+		Start()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, process.Wait())
+
+	err = process.WaitForOutput(context.TODO(), regexp.MustCompile("ready"))
+	assert.Error(t, err)
+
+	assert.NoError(t, process.Stop(time.Second))
+}
+
+func TestProcessKill(t *testing.T) {
+	// Ignoring the termination signal is inherited by the spawned process.
+	process, err := New("sh").
+		WithArguments("-c", "trap '' TERM; echo ready; sleep 30; echo done").
+		Start()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*10)
+	defer cancel()
+
+	assert.NoError(t, process.WaitForOutput(ctx, regexp.MustCompile("ready")))
+
+	start := time.Now()
+
+	err = process.Stop(time.Millisecond * 500)
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= time.Millisecond*500)
+	assert.True(t, time.Since(start) < time.Second*10)
+
+	select {
+	case <-process.Done():
+	default:
+		assert.Fail(t, "process should have exited")
+	}
+}
+
+func TestProcessWaitForPort(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*10)
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer listener.Close()
+
+	process, err := New("sleep").
+		WithArguments("30").
+		Start()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer process.Stop(time.Second) //nolint:errcheck
+
+	err = process.WaitForPort(ctx, listener.Addr().String())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,38 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return signalProcessGroup(cmd, syscall.SIGTERM)
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return signalProcessGroup(cmd, syscall.SIGKILL)
+}
+
+// processGroupAlive checks if any process of the group is still running.
+// The command itself may have exited while processes it spawned are still running.
+func processGroupAlive(cmd *exec.Cmd, _ <-chan struct{}) bool {
+	return syscall.Kill(-cmd.Process.Pid, 0) == nil
+}
+
+func signalProcessGroup(cmd *exec.Cmd, signal syscall.Signal) error {
+	// A negative PID signals all processes of the process group.
+	err := syscall.Kill(-cmd.Process.Pid, signal)
+	if errors.Is(err, syscall.ESRCH) {
+		return nil
+	}
+
+	return err
+}
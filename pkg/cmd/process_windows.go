@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os/exec"
+)
+
+// Process groups aren't supported on Windows, only the process itself is stopped.
+func setProcessGroup(*exec.Cmd) {}
+
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return killProcessGroup(cmd)
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	// The process may already have exited, which is reported as an error.
+	_ = cmd.Process.Kill()
+
+	return nil
+}
+
+func processGroupAlive(_ *exec.Cmd, done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,225 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+// backend fetches resources for debugging.
+type backend interface {
+	// listableResources returns all namespaced or all cluster-scoped resources that can be listed.
+	listableResources(namespaced bool) ([]schema.GroupVersionResource, error)
+
+	// writeResources writes all objects of the resources in a namespace as a YAML list.
+	// The namespace is empty for cluster-scoped resources.
+	writeResources(namespace string, resources []schema.GroupVersionResource, output io.Writer) error
+}
+
+// kubectlBackend fetches resources by running kubectl with the kubeconfig of a client.
+// The versions of the resources are left to kubectl.
+type kubectlBackend struct {
+	ctx            context.Context
+	execCommand    func(name string, arg ...string) *exec.Cmd
+	kubectlPath    string
+	kubeConfigPath string
+	stderr         io.Writer
+}
+
+func (k kubectlBackend) listableResources(namespaced bool) ([]schema.GroupVersionResource, error) {
+	cmd := k.execCommand(
+		k.kubectlPath,
+		"--kubeconfig",
+		k.kubeConfigPath,
+		"api-resources",
+		"--verbs=list",
+		fmt.Sprintf("--namespaced=%t", namespaced),
+		"-o",
+		"name")
+	var output bytes.Buffer
+
+	cmd.Stdout = &output
+	cmd.Stderr = k.stderr
+
+	if err := runWithContext(k.ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	var resources []schema.GroupVersionResource
+
+	for _, name := range strings.Split(output.String(), "\n") {
+		if name == "" {
+			continue
+		}
+
+		resources = append(resources, schema.ParseGroupResource(name).WithVersion(""))
+	}
+
+	return resources, nil
+}
+
+func (k kubectlBackend) writeResources(
+	namespace string, resources []schema.GroupVersionResource, output io.Writer) error {
+	args := []string{"--kubeconfig", k.kubeConfigPath, "get", resourceNames(resources)}
+
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+
+	cmd := k.execCommand(k.kubectlPath, append(args, "--ignore-not-found", "-o", "yaml")...)
+	cmd.Stdout = output
+	cmd.Stderr = k.stderr
+
+	return runWithContext(k.ctx, cmd)
+}
+
+// runWithContext runs a command and kills it when the context is done.
+func runWithContext(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		<-done
+
+		return ctx.Err()
+	}
+}
+
+// clientBackend fetches resources through the discovery and dynamic clients of a client,
+// using the versions preferred by the server.
+type clientBackend struct {
+	client client.Client
+	stderr io.Writer
+}
+
+func (c clientBackend) listableResources(namespaced bool) ([]schema.GroupVersionResource, error) {
+	groups, lists, err := c.client.Kubernetes.Discovery().ServerGroupsAndResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+
+		// Continue with the groups that could be discovered.
+		_, _ = fmt.Fprintf(c.stderr, "%v\n", err)
+	}
+
+	preferredVersions := make(map[string]string, len(groups))
+
+	for _, group := range groups {
+		preferredVersions[group.Name] = group.PreferredVersion.GroupVersion
+	}
+
+	var resources []schema.GroupVersionResource
+
+	for _, list := range lists {
+		groupVersion, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		if preferredVersions[groupVersion.Group] != list.GroupVersion {
+			continue
+		}
+
+		for _, resource := range list.APIResources {
+			// Subresources can't be listed on their own.
+			if resource.Namespaced != namespaced || strings.Contains(resource.Name, "/") || !hasVerb(resource, "list") {
+				continue
+			}
+
+			resources = append(resources, groupVersion.WithResource(resource.Name))
+		}
+	}
+
+	return resources, nil
+}
+
+func (c clientBackend) writeResources(
+	namespace string, resources []schema.GroupVersionResource, output io.Writer) error {
+	if c.client.Dynamic == nil {
+		return fmt.Errorf("client has no dynamic client")
+	}
+
+	items := []interface{}{}
+
+	var errs []error
+
+	// Resources that can't be listed are skipped, so that the others are still written.
+	for _, resource := range resources {
+		list, err := c.client.Dynamic.
+			Resource(resource).
+			Namespace(namespace).
+			List(c.client.Ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s: %w", resource.GroupResource(), err))
+			continue
+		}
+
+		for _, item := range list.Items {
+			items = append(items, item.Object)
+		}
+	}
+
+	// Like 'kubectl get --ignore-not-found', nothing is written if no objects exist.
+	if len(items) == 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	data, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := output.Write(data); err != nil {
+		return err
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// resourceNames returns the resources in the notation used by kubectl, e.g. "pods,instances.kudo.dev".
+func resourceNames(resources []schema.GroupVersionResource) string {
+	names := make([]string, 0, len(resources))
+
+	for _, resource := range resources {
+		names = append(names, resource.GroupResource().String())
+	}
+
+	return strings.Join(names, ",")
+}
+
+func hasVerb(resource metav1.APIResource, verb string) bool {
+	for _, v := range resource.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+
+	return false
+}
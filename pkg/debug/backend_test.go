@@ -0,0 +1,200 @@
+package debug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func testObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}
+}
+
+func testDiscovery() []*metav1.APIResourceList {
+	return []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "pods/log", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+				{Name: "secrets", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+				{Name: "services", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+				{Name: "bindings", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+				{Name: "nodes", Namespaced: false, Verbs: metav1.Verbs{"list"}},
+			},
+		},
+		{
+			GroupVersion: "kudo.dev/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "instances", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			},
+		},
+		{
+			// Not the preferred version of the group.
+			GroupVersion: "kudo.dev/v1alpha1",
+			APIResources: []metav1.APIResource{
+				{Name: "instances", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			},
+		},
+	}
+}
+
+func TestCollectArtifacts_Client(t *testing.T) {
+	d := debugDeps{
+		artifactsDirectoryBase: "/artifacts",
+		now:                    func() time.Time { return time.Time{} },
+	}
+
+	fake := client.NewFake(
+		testObject("v1", "Pod", "ns", "pod-b"),
+		testObject("v1", "Pod", "ns", "pod-a"),
+		testObject("v1", "Pod", "other", "pod-c"),
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"),
+	)
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	err := d.collectArtifacts(fake.Client, fs, &sb, "ns", "")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"collecting namespaced resources for debugging...\n"+
+			"collecting pod logs for debugging...\n",
+		sb.String())
+
+	content, err := afero.ReadFile(fs, "/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, `apiVersion: v1
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pod-a
+    namespace: ns
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pod-b
+    namespace: ns
+kind: List
+`, string(content))
+	}
+
+	content, err = afero.ReadFile(fs, "/artifacts/ns-0001-01-01T00-00-00Z/resources-kudo.dev.yaml")
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(content), "name: kafka")
+	}
+}
+
+func TestCollectArtifacts_ClientFailure(t *testing.T) {
+	d := debugDeps{
+		artifactsDirectoryBase: "/artifacts",
+		now:                    func() time.Time { return time.Time{} },
+	}
+
+	fake := client.NewFake(
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"),
+		testObject("v1", "Pod", "ns", "kafka-0"))
+	fake.FakeKubernetes.Resources = testDiscovery()
+	fake.FailOn("list", "services", errors.New("forbidden"))
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	err := d.collectArtifacts(fake.Client, fs, &sb, "ns", "")
+	assert.Contains(t, sb.String(), "fetching pods,secrets,services failed: failed to list services: forbidden\n")
+
+	var collectionErr CollectionError
+
+	if assert.True(t, errors.As(err, &collectionErr)) && assert.Len(t, collectionErr.Failures, 1) {
+		assert.Equal(t, "ns", collectionErr.Namespace)
+		assert.Equal(t, "resources.yaml", collectionErr.Failures[0].Path)
+		assert.EqualError(t, collectionErr.Failures[0].Err,
+			"fetching pods,secrets,services failed: failed to list services: forbidden")
+	}
+
+	content, err := afero.ReadFile(fs, "/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml")
+	if assert.NoError(t, err, "resources that could be listed should still be written") {
+		assert.Contains(t, string(content), "name: kafka-0")
+	}
+
+	exists, err := afero.Exists(fs, "/artifacts/ns-0001-01-01T00-00-00Z/resources-kudo.dev.yaml")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestCollectArtifacts_Options(t *testing.T) {
+	d := debugDeps{
+		artifactsDirectoryBase: "/artifacts",
+		now:                    func() time.Time { return time.Time{} },
+	}
+
+	transition := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeDiskPressure,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(transition),
+					Reason:             "KubeletHasDiskPressure",
+					Message:            "kubelet has disk pressure",
+				},
+			},
+		},
+	}
+
+	fake := client.NewFake(
+		&node,
+		testObject("v1", "Pod", "ns", "pod-a"),
+		testObject("v1", "Pod", "kudo-system", "kudo-controller-manager-0"),
+		testObject("v1", "Node", "", "node-1"),
+	)
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	err := d.collectArtifacts(fake.Client, fs, &sb, "ns", "",
+		CollectClusterResources(), CollectNodes(), CollectKUDOManager())
+	assert.NoError(t, err)
+
+	for file, expected := range map[string]string{
+		"/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml":                        "name: pod-a",
+		"/artifacts/ns-0001-01-01T00-00-00Z/namespaces/kudo-system/resources.yaml": "name: kudo-controller-manager-0",
+		"/artifacts/ns-0001-01-01T00-00-00Z/cluster/resources.yaml":                "name: node-1",
+		"/artifacts/ns-0001-01-01T00-00-00Z/cluster/nodes.txt": "node-1  DiskPressure  True    2020-10-01T12:00:00Z  " +
+			"KubeletHasDiskPressure  kubelet has disk pressure",
+	} {
+		content, err := afero.ReadFile(fs, file)
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(content), expected, "file %q has unexpected content", file)
+		}
+	}
+
+	content, err := afero.ReadFile(fs, "/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml")
+	if assert.NoError(t, err) {
+		assert.NotContains(t, string(content), "kudo-controller-manager-0")
+	}
+}
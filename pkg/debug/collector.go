@@ -0,0 +1,232 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+// collector collects the artifacts of a single cluster.
+type collector struct {
+	client  client.Client
+	backend backend
+	fs      afero.Fs
+	writer  io.Writer
+	config  CollectConfig
+	// redactor is nil if redaction is disabled.
+	redactor *redactor
+	// limit holds a token for every resource group that is being fetched, nil if unlimited.
+	limit    chan struct{}
+	manifest *manifestRecorder
+	now      func() time.Time
+}
+
+// collect stores the artifacts of a namespace in a directory.
+// Additional namespaces are stored in its 'namespaces/<namespace>' subdirectories,
+// cluster-scoped resources and node conditions in its 'cluster' subdirectory.
+// Failures are reported and collection continues with the remaining artifacts.
+func (c collector) collect(namespace, artifactsDirectory string) {
+	c.collectNamespace(namespace, artifactsDirectory)
+
+	for _, additionalNamespace := range c.config.Namespaces {
+		_, _ = fmt.Fprintf(c.writer, "collecting artifacts of namespace %s for debugging...\n", additionalNamespace)
+
+		c.collectNamespace(additionalNamespace, path.Join(artifactsDirectory, "namespaces", additionalNamespace))
+	}
+
+	clusterDirectory := path.Join(artifactsDirectory, "cluster")
+
+	if c.config.ClusterResources {
+		_, _ = fmt.Fprintf(c.writer, "collecting cluster-scoped resources for debugging...\n")
+
+		if err := c.collectResourceFiles("", clusterDirectory); err != nil {
+			c.report(clusterDirectory, fmt.Errorf("collection of cluster-scoped resources for debugging failed: %w", err))
+		}
+	}
+
+	if c.config.Nodes && c.client.Kubernetes != nil {
+		if err := c.collectNodeConditions(clusterDirectory); err != nil {
+			c.report(clusterDirectory, fmt.Errorf("collection of node conditions for debugging failed: %w", err))
+		}
+	}
+}
+
+// collectNamespace stores the resources and pod artifacts of a namespace in a directory.
+func (c collector) collectNamespace(namespace, directory string) {
+	_, _ = fmt.Fprintf(c.writer, "collecting namespaced resources for debugging...\n")
+
+	if err := c.collectResourceFiles(namespace, directory); err != nil {
+		c.report(directory, fmt.Errorf("collection of resources for debugging failed: %w", err))
+	}
+
+	// Logs are fetched with the Kubernetes client, a client only providing a kubeconfig for kubectl has none.
+	if c.client.Kubernetes == nil || !c.selected(schema.GroupVersionResource{Resource: "pods"}) {
+		return
+	}
+
+	if err := c.collectPodArtifacts(namespace, directory); err != nil {
+		c.report(directory, fmt.Errorf("collection of pod logs for debugging failed: %w", err))
+	}
+}
+
+// collectResourceFiles stores the resources of a namespace, or the cluster-scoped resources if the namespace is empty,
+// in one file per API group. Failures of single API groups are reported, they don't fail the whole collection.
+func (c collector) collectResourceFiles(namespace, directory string) error {
+	resources, err := c.backend.listableResources(namespace != "")
+	if err != nil {
+		return fmt.Errorf("fetching API resource types failed: %w", err)
+	}
+
+	err = c.fs.MkdirAll(directory, 0777)
+	if err != nil {
+		return fmt.Errorf("creating %q failed: %v", directory, err)
+	}
+
+	var wg sync.WaitGroup
+
+	for group, resources := range groupResources(resources, c.selected) {
+		var fileName string
+
+		if group == "" {
+			fileName = "resources.yaml"
+		} else {
+			fileName = fmt.Sprintf("resources-%s.yaml", group)
+		}
+
+		wg.Add(1)
+
+		go c.collectResources(&wg, namespace, fileName, resources, directory)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (c collector) collectResources(
+	wg *sync.WaitGroup,
+	namespace, fileName string,
+	resources []schema.GroupVersionResource,
+	directoryName string) {
+	defer wg.Done()
+
+	if c.limit != nil {
+		c.limit <- struct{}{}
+
+		defer func() { <-c.limit }()
+	}
+
+	var output bytes.Buffer
+
+	outPath := path.Join(directoryName, fileName)
+	started := c.now()
+
+	err := c.backend.writeResources(namespace, resources, &output)
+	if err != nil {
+		c.report(outPath, fmt.Errorf("fetching %s failed: %w", resourceNames(resources), err))
+	}
+
+	if output.Len() == 0 {
+		return
+	}
+
+	data := output.Bytes()
+
+	if c.redactor != nil {
+		if data, err = c.redactor.redact(data); err != nil {
+			c.report(outPath, fmt.Errorf("not writing %s: %w", resourceNames(resources), err))
+			return
+		}
+	}
+
+	c.writeFile(outPath, data, started)
+}
+
+// selected checks if a resource is included and not excluded.
+func (c collector) selected(resource schema.GroupVersionResource) bool {
+	return resourceSelected(resource, c.config.IncludeResources, c.config.ExcludeResources)
+}
+
+// resourceSelected checks if a resource is included and not excluded. No resources are included means all are.
+// Resources can be referred to by their name, e.g. "pods", or by their name and group, e.g. "instances.kudo.dev".
+func resourceSelected(resource schema.GroupVersionResource, include, exclude []string) bool {
+	names := []string{resource.Resource, resource.GroupResource().String()}
+
+	if len(include) > 0 && !containsAny(include, names) {
+		return false
+	}
+
+	return !containsAny(exclude, names)
+}
+
+// groupResources groups the selected resources by API group, sorted by name within each group.
+func groupResources(
+	resources []schema.GroupVersionResource,
+	selected func(schema.GroupVersionResource) bool) map[string][]schema.GroupVersionResource {
+	groupedResources := make(map[string][]schema.GroupVersionResource)
+
+	for _, resource := range resources {
+		if selected(resource) {
+			groupedResources[resource.Group] = append(groupedResources[resource.Group], resource)
+		}
+	}
+
+	for _, resources := range groupedResources {
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].Resource < resources[j].Resource
+		})
+	}
+
+	return groupedResources
+}
+
+// writeFile stores data fetched since started in a file and adds the file to the manifest.
+func (c collector) writeFile(outPath string, data []byte, started time.Time) {
+	if err := afero.WriteFile(c.fs, outPath, data, 0666); err != nil {
+		c.report(outPath, fmt.Errorf("writing %q failed: %w", outPath, err))
+		return
+	}
+
+	c.manifest.addFile(outPath, len(data), c.now().Sub(started))
+}
+
+// report prints an error encountered while collecting the file or directory at a path and adds it to the manifest.
+func (c collector) report(filePath string, err error) {
+	_, _ = fmt.Fprintf(c.writer, "%v\n", err)
+
+	c.manifest.addError(filePath, err)
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// synchronizedWriter allows concurrent writes to a writer.
+type synchronizedWriter struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func (w *synchronizedWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.writer.Write(p)
+}
@@ -1,14 +1,12 @@
 package debug
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path"
-	"sort"
-	"strings"
-	"sync"
 	"time"
 
 	"github.com/spf13/afero"
@@ -27,145 +25,347 @@ type debugDeps struct {
 	now                    func() time.Time
 }
 
+// ArtifactsBuilder tracks the options set for collecting debugging artifacts of a namespace.
+type ArtifactsBuilder struct {
+	CollectConfig
+
+	Namespace string
+	Fs        afero.Fs
+	Writer    io.Writer
+}
+
+// BuildArtifacts creates a builder for collecting debugging artifacts of a namespace.
+// By default, artifacts are written to $TEST_ARTIFACTS_DIRECTORY on the local filesystem using client-go,
+// and progress and errors are reported to stderr. The data of Secrets is replaced by its keyed hash and length.
+// For example, to collect artifacts outside of CI:
+//   err := debug.BuildArtifacts(namespace).
+//   	WithDirectory("/tmp/artifacts").
+//   	WithKUDOManager().
+//   	WithExcludedResources("events", "events.events.k8s.io").
+//   	WithLogTailLines(1000).
+//   	WithTimeout(2 * time.Minute).
+//   	WithArchive().
+//   	Do(client)
+func BuildArtifacts(namespace string) ArtifactsBuilder {
+	return ArtifactsBuilder{
+		CollectConfig: CollectConfig{
+			Directory: os.Getenv(testArtifactsDirectoryVarName),
+		},
+		Namespace: namespace,
+		Fs:        afero.NewOsFs(),
+		Writer:    os.Stderr,
+	}
+}
+
+// WithDirectory sets the base directory of the artifacts.
+func (builder ArtifactsBuilder) WithDirectory(directory string) ArtifactsBuilder {
+	builder.Directory = directory
+	return builder
+}
+
+// WithFs sets the filesystem the artifacts are written to.
+func (builder ArtifactsBuilder) WithFs(fs afero.Fs) ArtifactsBuilder {
+	builder.Fs = fs
+	return builder
+}
+
+// WithWriter sets the writer progress and errors are reported to, e.g. GinkgoWriter.
+func (builder ArtifactsBuilder) WithWriter(writer io.Writer) ArtifactsBuilder {
+	builder.Writer = writer
+	return builder
+}
+
+// WithKubectl fetches resources with a kubectl binary instead of client-go.
+// This requires a client with a kubeconfig file.
+func (builder ArtifactsBuilder) WithKubectl(kubectlPath string) ArtifactsBuilder {
+	builder.KubectlPath = kubectlPath
+	return builder
+}
+
+// WithNamespaces collects the artifacts of additional namespaces.
+func (builder ArtifactsBuilder) WithNamespaces(namespaces ...string) ArtifactsBuilder {
+	builder.Namespaces = append(append([]string{}, builder.Namespaces...), namespaces...)
+	return builder
+}
+
+// WithKUDOManager collects the artifacts of the KUDO manager's namespace.
+func (builder ArtifactsBuilder) WithKUDOManager() ArtifactsBuilder {
+	return builder.WithNamespaces(kudoManagerNamespace)
+}
+
+// WithClusterResources collects cluster-scoped resources.
+func (builder ArtifactsBuilder) WithClusterResources() ArtifactsBuilder {
+	builder.ClusterResources = true
+	return builder
+}
+
+// WithNodes collects a summary of the conditions of all nodes.
+func (builder ArtifactsBuilder) WithNodes() ArtifactsBuilder {
+	builder.Nodes = true
+	return builder
+}
+
+// WithIncludedResources only collects these resources, e.g. "pods" or "instances.kudo.dev".
+// Pod logs are only collected if pods are included.
+func (builder ArtifactsBuilder) WithIncludedResources(resources ...string) ArtifactsBuilder {
+	builder.IncludeResources = append(append([]string{}, builder.IncludeResources...), resources...)
+	return builder
+}
+
+// WithExcludedResources doesn't collect these resources, e.g. "events" or "operatorversions.kudo.dev".
+// Pod logs are not collected if pods are excluded.
+func (builder ArtifactsBuilder) WithExcludedResources(resources ...string) ArtifactsBuilder {
+	builder.ExcludeResources = append(append([]string{}, builder.ExcludeResources...), resources...)
+	return builder
+}
+
+// WithRedactedPaths redacts the fields selected by JSONPath expressions in addition to Secret data.
+// Each expression has to end with a field, or a wildcard to select all fields of an object, e.g.
+//   builder.WithRedactedPaths(
+//   	`{.spec.template.spec.containers[*].env[?(@.name=="PASSWORD")].value}`,
+//   	`{.metadata.annotations['example.com/token']}`,
+//   	`{.spec.credentials.*}`)
+func (builder ArtifactsBuilder) WithRedactedPaths(paths ...string) ArtifactsBuilder {
+	builder.RedactPaths = append(append([]string{}, builder.RedactPaths...), paths...)
+	return builder
+}
+
+// WithoutRedaction collects Secret data and other sensitive fields unchanged.
+// Only use this if the artifacts are not accessible to others.
+func (builder ArtifactsBuilder) WithoutRedaction() ArtifactsBuilder {
+	builder.DisableRedaction = true
+	return builder
+}
+
+// WithLogTailLines limits the number of lines collected from the end of each container log.
+func (builder ArtifactsBuilder) WithLogTailLines(lines int64) ArtifactsBuilder {
+	builder.LogTailLines = &lines
+	return builder
+}
+
+// WithTimeout limits the time spent on collecting artifacts.
+func (builder ArtifactsBuilder) WithTimeout(timeout time.Duration) ArtifactsBuilder {
+	builder.Timeout = timeout
+	return builder
+}
+
+// WithConcurrency limits the number of resource groups fetched at the same time.
+func (builder ArtifactsBuilder) WithConcurrency(concurrency int) ArtifactsBuilder {
+	builder.Concurrency = concurrency
+	return builder
+}
+
+// WithArchive stores the artifacts in a single '<namespace>-<time>.tar.gz' file instead of a directory.
+// The manifest of the artifacts is also stored next to the archive in '<namespace>-<time>.manifest.json'.
+func (builder ArtifactsBuilder) WithArchive() ArtifactsBuilder {
+	builder.Archive = true
+	return builder
+}
+
+// Do collects the artifacts.
+// Encountered errors are reported to the writer, collection continues after errors where possible.
+// If some artifacts couldn't be collected, a CollectionError listing the failures is returned.
+func (builder ArtifactsBuilder) Do(client client.Client) error {
+	return debugDeps{
+		artifactsDirectoryBase: builder.Directory,
+		execCommand:            exec.Command,
+		now:                    time.Now,
+	}.collect(client, builder)
+}
+
+// DoForRegistry collects the artifacts in all clusters of a registry.
+// The artifacts of each cluster are stored in a subdirectory named after the cluster.
+func (builder ArtifactsBuilder) DoForRegistry(registry *client.Registry) error {
+	return debugDeps{
+		artifactsDirectoryBase: builder.Directory,
+		execCommand:            exec.Command,
+		now:                    time.Now,
+	}.collectForRegistry(registry, builder)
+}
+
 // CollectArtifacts collects useful debugging artifacts from a given namespace.
-// Should typically be called if CurrentGinkgoTestDescription().Failed, like this:
+// Should typically be called if CurrentGinkgoTestDescription().Failed, like this, or use the DoOnFailure and
+// DoForTest methods of BuildArtifacts instead:
 //   c, err := client.NewForConfig(KubeConfigPath)
 //   if err != nil ...
 //   debug.CollectArtifacts(c, afero.NewOsFs(), GinkgoWriter, TestNamespace, KubectlPath)
+// If kubectlPath is empty, resources are fetched with the discovery and dynamic clients of the client instead of
+// kubectl. This also works for in-cluster clients, which don't have a kubeconfig file.
+// CollectOptions add artifacts from outside the namespace, e.g.
+//   debug.CollectArtifacts(c, afero.NewOsFs(), GinkgoWriter, TestNamespace, "",
+//   	debug.CollectKUDOManager(), debug.CollectNodes())
+// Use BuildArtifacts for more options.
 // Note that this function emits encountered errors to the supplied writer, so there is only a need to inspect its
 // return value only if the caller wants to take some action in addition to printing the error.
-func CollectArtifacts(client client.Client, fs afero.Fs, writer io.Writer, namespace, kubectlPath string) error {
+// Failures of single artifacts are returned as a CollectionError.
+func CollectArtifacts(
+	client client.Client, fs afero.Fs, writer io.Writer, namespace, kubectlPath string, options ...CollectOption) error {
 	return debugDeps{
 		artifactsDirectoryBase: os.Getenv(testArtifactsDirectoryVarName),
 		execCommand:            exec.Command,
 		now:                    time.Now,
-	}.collectArtifacts(client, fs, writer, namespace, kubectlPath)
+	}.collectArtifacts(client, fs, writer, namespace, kubectlPath, options...)
 }
 
-func (d debugDeps) collectArtifacts(
-	client client.Client, fs afero.Fs, writer io.Writer, namespace, kubectlPath string) error {
-	err := d.collectNamespacedResources(client, fs, writer, namespace, kubectlPath)
-	if err != nil {
-		_, _ = fmt.Fprintf(writer, "collection of resources for debugging failed: %v\n", err)
-	}
+// CollectClusterArtifacts collects useful debugging artifacts from a given namespace in all clusters of a registry.
+// The artifacts of each cluster are stored in a subdirectory named after the cluster.
+func CollectClusterArtifacts(
+	registry *client.Registry,
+	fs afero.Fs,
+	writer io.Writer,
+	namespace, kubectlPath string,
+	options ...CollectOption) error {
+	return debugDeps{
+		artifactsDirectoryBase: os.Getenv(testArtifactsDirectoryVarName),
+		execCommand:            exec.Command,
+		now:                    time.Now,
+	}.collectClusterArtifacts(registry, fs, writer, namespace, kubectlPath, options...)
+}
 
-	return err
+func (d debugDeps) collectClusterArtifacts(
+	registry *client.Registry,
+	fs afero.Fs,
+	writer io.Writer,
+	namespace, kubectlPath string,
+	options ...CollectOption) error {
+	return d.collectForRegistry(registry, newArtifactsBuilder(fs, writer, namespace, kubectlPath, options))
 }
 
-func (d debugDeps) collectNamespacedResources(
-	client client.Client, fs afero.Fs, writer io.Writer, namespace, kubectlPath string) error {
-	if d.artifactsDirectoryBase == "" {
-		return fmt.Errorf("$%s not set", testArtifactsDirectoryVarName)
+func (d debugDeps) collectArtifacts(
+	client client.Client,
+	fs afero.Fs,
+	writer io.Writer,
+	namespace, kubectlPath string,
+	options ...CollectOption) error {
+	return d.collect(client, newArtifactsBuilder(fs, writer, namespace, kubectlPath, options))
+}
+
+func newArtifactsBuilder(
+	fs afero.Fs, writer io.Writer, namespace, kubectlPath string, options []CollectOption) ArtifactsBuilder {
+	builder := ArtifactsBuilder{
+		CollectConfig: CollectConfig{
+			KubectlPath: kubectlPath,
+		},
+		Namespace: namespace,
+		Fs:        fs,
+		Writer:    writer,
 	}
 
-	_, _ = fmt.Fprintf(writer, "collecting namespaced resources for debugging...\n")
-
-	cmd := d.execCommand(
-		kubectlPath,
-		"--kubeconfig",
-		client.KubeConfigPath,
-		"api-resources",
-		"--verbs=list",
-		"--namespaced=true",
-		"-o",
-		"name")
-	cmd.Stderr = writer
-
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("fetching API resource types failed: %v", err)
+	for _, option := range options {
+		option(&builder.CollectConfig)
 	}
 
-	artifactsDirectory := path.Join(
-		d.artifactsDirectoryBase, fmt.Sprintf("%s-%s", namespace, d.now().Format(time.RFC3339)))
+	return builder
+}
 
-	err = fs.MkdirAll(artifactsDirectory, 0777)
-	if err != nil {
-		return fmt.Errorf("creating %q failed: %v", artifactsDirectory, err)
+func (d debugDeps) collectForRegistry(registry *client.Registry, builder ArtifactsBuilder) error {
+	if d.artifactsDirectoryBase == "" {
+		return reportMissingDirectory(builder.Writer)
 	}
 
-	resources := strings.Split(string(output), "\n")
-	groupedResources := make(map[string][]string)
+	return registry.ForEach(func(name string, client client.Client) error {
+		clusterDeps := d
+		clusterDeps.artifactsDirectoryBase = path.Join(d.artifactsDirectoryBase, directoryName(name))
 
-	for _, resources := range resources {
-		if resources == "" {
-			continue
-		}
-
-		resourcesAndGroup := strings.SplitN(resources, ".", 2)
+		return clusterDeps.collect(client, builder)
+	})
+}
 
-		var group string
+// reportMissingDirectory reports that artifacts can't be collected without a base directory.
+func reportMissingDirectory(writer io.Writer) error {
+	err := fmt.Errorf("$%s not set", testArtifactsDirectoryVarName)
+	_, _ = fmt.Fprintf(writer, "collection of resources for debugging failed: %v\n", err)
 
-		if len(resourcesAndGroup) == 2 {
-			group = resourcesAndGroup[1]
-		}
+	return err
+}
 
-		groupedResources[group] = append(groupedResources[group], resources)
+// collect stores the artifacts of the namespace in '<base>/<namespace>-<time>', together with a manifest listing
+// the collected files and encountered errors. The directory is archived to '<base>/<namespace>-<time>.tar.gz'
+// if requested.
+func (d debugDeps) collect(client client.Client, builder ArtifactsBuilder) error {
+	if d.artifactsDirectoryBase == "" {
+		return reportMissingDirectory(builder.Writer)
 	}
 
-	var wg sync.WaitGroup
+	writer := &synchronizedWriter{writer: builder.Writer}
 
-	for group, resourcesSlice := range groupedResources {
-		sort.Strings(resourcesSlice)
+	ctx := client.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-		resources := strings.Join(resourcesSlice, ",")
+	if builder.Timeout > 0 {
+		var cancel context.CancelFunc
 
-		var fileName string
+		ctx, cancel = context.WithTimeout(ctx, builder.Timeout)
+		defer cancel()
+	}
 
-		if group == "" {
-			fileName = "resources.yaml"
-		} else {
-			fileName = fmt.Sprintf("resources-%s.yaml", group)
-		}
+	client.Ctx = ctx
 
-		wg.Add(1)
+	artifactsDirectory := path.Join(d.artifactsDirectoryBase,
+		fmt.Sprintf("%s-%s", builder.Namespace, d.now().UTC().Format(artifactsTimeFormat)))
 
-		go d.collectResources(client, fs, writer, &wg, namespace, fileName, resources, artifactsDirectory, kubectlPath)
+	c := collector{
+		client:   client,
+		backend:  d.backend(ctx, client, writer, builder.KubectlPath),
+		fs:       builder.Fs,
+		writer:   writer,
+		config:   builder.CollectConfig,
+		manifest: newManifestRecorder(builder.Namespace, artifactsDirectory, d.now()),
+		now:      d.now,
 	}
 
-	wg.Wait()
+	if builder.Concurrency > 0 {
+		c.limit = make(chan struct{}, builder.Concurrency)
+	}
 
-	return nil
-}
+	if !builder.DisableRedaction {
+		redactor, err := newRedactor(builder.RedactPaths)
+		if err != nil {
+			_, _ = fmt.Fprintf(writer, "collection of resources for debugging failed: %v\n", err)
 
-func (d debugDeps) collectResources(
-	client client.Client,
-	fs afero.Fs,
-	writer io.Writer,
-	wg *sync.WaitGroup,
-	namespace, fileName,
-	resourcesNames,
-	directoryName,
-	kubectlPath string) {
-	defer wg.Done()
-
-	outPath := path.Join(directoryName, fileName)
-
-	output, err := fs.Create(outPath)
-	if err != nil {
-		_, _ = fmt.Fprintf(writer, "creating %q failed: %v\n", outPath, err)
-		return
+			return err
+		}
+
+		c.redactor = redactor
 	}
 
-	cmd := d.execCommand(kubectlPath, "--kubeconfig", client.KubeConfigPath, "get", resourcesNames,
-		"--namespace", namespace, "--ignore-not-found", "-o", "yaml")
-	cmd.Stdout = output
-	cmd.Stderr = writer
+	c.collect(builder.Namespace, artifactsDirectory)
 
-	err = cmd.Run()
-	if err != nil {
-		_, _ = fmt.Fprintf(writer, "fetching %s failed: %v\n", resourcesNames, err)
+	if err := c.writeManifest(path.Join(artifactsDirectory, manifestFileName)); err != nil {
+		c.report(artifactsDirectory, fmt.Errorf("collection of resources for debugging failed: %w", err))
 	}
 
-	empty, err := afero.IsEmpty(fs, outPath)
-	if err != nil {
-		_, _ = fmt.Fprintf(writer, "checking %s for emptiness failed: %v\n", outPath, err)
+	if builder.Archive {
+		if err := archiveDirectory(c.fs, artifactsDirectory); err != nil {
+			c.report(artifactsDirectory, fmt.Errorf("collection of resources for debugging failed: %w", err))
+		}
+
+		// The manifest is also stored next to the archive, so that it can be read without extracting the archive.
+		// It includes errors of the archiving itself.
+		if err := c.writeManifest(artifactsDirectory + archivedManifestSuffix); err != nil {
+			c.report(artifactsDirectory, fmt.Errorf("collection of resources for debugging failed: %w", err))
+		}
 	}
 
-	if empty {
-		err = fs.Remove(outPath)
-		if err != nil {
-			_, _ = fmt.Fprintf(writer, "removing empty %s failed: %v\n", outPath, err)
+	return c.manifest.err()
+}
+
+// backend returns the kubectl backend if a kubectl path is set, the client-go backend otherwise.
+func (d debugDeps) backend(ctx context.Context, client client.Client, writer io.Writer, kubectlPath string) backend {
+	if kubectlPath == "" {
+		return clientBackend{
+			client: client,
+			stderr: writer,
 		}
 	}
+
+	return kubectlBackend{
+		ctx:            ctx,
+		execCommand:    d.execCommand,
+		kubectlPath:    kubectlPath,
+		kubeConfigPath: client.KubeConfigPath,
+		stderr:         writer,
+	}
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -11,6 +13,8 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -33,6 +37,7 @@ type testStruct struct {
 	getExit         map[string]int
 	expectedDirs    []string
 	expectedFiles   map[string]string
+	expectedErr     string
 }
 
 func TestCollectArtifacts(t *testing.T) {
@@ -50,9 +55,10 @@ func TestCollectArtifacts(t *testing.T) {
 			nil,
 			[]string{
 				"/artifacts",
-				"/artifacts/ns-0001-01-01T00:00:00Z",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
 			nil,
+			"",
 		},
 		{
 			"one resource no output",
@@ -65,9 +71,10 @@ func TestCollectArtifacts(t *testing.T) {
 			nil,
 			[]string{
 				"/artifacts",
-				"/artifacts/ns-0001-01-01T00:00:00Z",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
 			nil,
+			"",
 		},
 		{
 			"one resource with output",
@@ -80,11 +87,12 @@ func TestCollectArtifacts(t *testing.T) {
 			nil,
 			[]string{
 				"/artifacts",
-				"/artifacts/ns-0001-01-01T00:00:00Z",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
 			map[string]string{
-				"/artifacts/ns-0001-01-01T00:00:00Z/resources.yaml": "pod1\n",
+				"/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml": "pod1\n",
 			},
+			"",
 		},
 		{
 			"two resources of same group",
@@ -99,11 +107,12 @@ func TestCollectArtifacts(t *testing.T) {
 			nil,
 			[]string{
 				"/artifacts",
-				"/artifacts/ns-0001-01-01T00:00:00Z",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
 			map[string]string{
-				"/artifacts/ns-0001-01-01T00:00:00Z/resources.yaml": "pod1\n",
+				"/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml": "pod1\n",
 			},
+			"",
 		},
 		{
 			"two groups of resources",
@@ -117,12 +126,13 @@ func TestCollectArtifacts(t *testing.T) {
 			nil,
 			[]string{
 				"/artifacts",
-				"/artifacts/ns-0001-01-01T00:00:00Z",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
 			map[string]string{
-				"/artifacts/ns-0001-01-01T00:00:00Z/resources-kudo.dev.yaml": "kudo\n",
-				"/artifacts/ns-0001-01-01T00:00:00Z/resources.yaml":          "stuff\n",
+				"/artifacts/ns-0001-01-01T00-00-00Z/resources-kudo.dev.yaml": "kudo\n",
+				"/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml":          "stuff\n",
 			},
+			"",
 		},
 		{
 			"two groups of resources of which one fails",
@@ -140,12 +150,34 @@ func TestCollectArtifacts(t *testing.T) {
 			},
 			[]string{
 				"/artifacts",
-				"/artifacts/ns-0001-01-01T00:00:00Z",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
 			map[string]string{
-				"/artifacts/ns-0001-01-01T00:00:00Z/resources-kudo.dev.yaml": "kudo\n",
-				"/artifacts/ns-0001-01-01T00:00:00Z/resources.yaml":          "peek...",
+				"/artifacts/ns-0001-01-01T00-00-00Z/resources-kudo.dev.yaml": "kudo\n",
+				"/artifacts/ns-0001-01-01T00-00-00Z/resources.yaml":          "peek...",
+			},
+			"failed to collect 1 artifacts of namespace ns: [resources.yaml: fetching gremlins,pods failed: exit status 1]",
+		},
+		{
+			"truncated secret of a failed fetch",
+			"secrets\n",
+			"collecting namespaced resources for debugging...\n" +
+				"fetching secrets failed: exit status 1\n" +
+				"not writing secrets: failed to redact object 0: object has no kind\n",
+			map[string]string{
+				"secrets": "apiVersion: v1\nitems:\n- apiVersion: v1\n  data:\n    password: c2VjcmV0\n",
+			},
+			nil,
+			map[string]int{
+				"secrets": 1,
+			},
+			[]string{
+				"/artifacts",
+				"/artifacts/ns-0001-01-01T00-00-00Z",
 			},
+			nil,
+			"failed to collect 2 artifacts of namespace ns: [resources.yaml: fetching secrets failed: exit status 1; " +
+				"resources.yaml: not writing secrets: failed to redact object 0: object has no kind]",
 		},
 	}
 
@@ -157,7 +189,11 @@ func TestCollectArtifacts(t *testing.T) {
 			d.execCommand = getExecCommand(t, test)
 
 			err := d.collectArtifacts(client.Client{KubeConfigPath: "kube.config"}, fs, &sb, "ns", "kubectl")
-			assert.NoError(t, err)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
 
 			assert.Equal(t, test.expectedOut, sb.String())
 			assert.NoError(t, afero.Walk(fs, "/", func(path string, info os.FileInfo, err error) error {
@@ -169,7 +205,7 @@ func TestCollectArtifacts(t *testing.T) {
 						return nil
 					}
 					assert.Contains(t, test.expectedDirs, path, "directory %q is not expected to exist", path)
-				} else {
+				} else if filepath.Base(path) != manifestFileName {
 					_, ok := test.expectedFiles[path]
 					assert.True(t, ok, "file %q is not expected to exist", path)
 				}
@@ -244,3 +280,150 @@ func TestMain(m *testing.M) {
 		os.Exit(m.Run())
 	}
 }
+
+func TestCollectClusterArtifacts_Disabled(t *testing.T) {
+	registry := client.NewRegistry()
+	assert.NoError(t, registry.Add("primary", client.Client{KubeConfigPath: "kube.config"}))
+
+	sb := strings.Builder{}
+	err := debugDeps{
+		artifactsDirectoryBase: "",
+	}.collectClusterArtifacts(registry, nil, &sb, "ns", "")
+	assert.EqualError(t, err, "$TEST_ARTIFACTS_DIRECTORY not set")
+	assert.Equal(t, sb.String(), "collection of resources for debugging failed: $TEST_ARTIFACTS_DIRECTORY not set\n")
+}
+
+func TestCollectClusterArtifacts(t *testing.T) {
+	d := debugDeps{
+		artifactsDirectoryBase: "/artifacts",
+		now:                    func() time.Time { return time.Time{} },
+	}
+	test := testStruct{
+		apiResourcesOut: "pods",
+		getOut: map[string]string{
+			"pods": "pod1\n",
+		},
+	}
+	d.execCommand = getExecCommand(t, test)
+
+	registry := client.NewRegistry()
+	assert.NoError(t, registry.Add("primary", client.Client{KubeConfigPath: "kube.config"}))
+	assert.NoError(t, registry.Add("arn:aws:eks:us-west-2:123456789012:cluster/secondary",
+		client.Client{KubeConfigPath: "kube.config"}))
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	err := d.collectClusterArtifacts(registry, fs, &sb, "ns", "kubectl")
+	assert.NoError(t, err)
+
+	for _, file := range []string{
+		"/artifacts/primary/ns-0001-01-01T00-00-00Z/resources.yaml",
+		"/artifacts/arn_aws_eks_us-west-2_123456789012_cluster_secondary/ns-0001-01-01T00-00-00Z/resources.yaml",
+	} {
+		content, err := afero.ReadFile(fs, file)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "pod1\n", string(content))
+		}
+	}
+}
+
+func TestArtifactsBuilder(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-0", Namespace: "ns"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "kafka",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+
+	secret := testObject("v1", "Secret", "ns", "credentials")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+
+	fake := client.NewFake(
+		&pod,
+		secret,
+		testObject("v1", "Pod", "ns", "kafka-0"),
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"),
+	).WithContainerLogs("ns", "kafka-0", "kafka", []byte("starting\nstarted\n"))
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	tests := []struct {
+		name     string
+		builder  func(ArtifactsBuilder) ArtifactsBuilder
+		expected map[string]string
+		missing  []string
+	}{
+		{
+			name: "included and excluded resources",
+			builder: func(builder ArtifactsBuilder) ArtifactsBuilder {
+				return builder.
+					WithIncludedResources("pods", "secrets", "instances.kudo.dev").
+					WithExcludedResources("pods")
+			},
+			expected: map[string]string{
+				"resources.yaml":          "name: credentials",
+				"resources-kudo.dev.yaml": "name: kafka",
+			},
+			missing: []string{"pods"},
+		},
+		{
+			name: "secret redaction",
+			builder: func(builder ArtifactsBuilder) ArtifactsBuilder {
+				return builder.WithIncludedResources("secrets")
+			},
+			expected: map[string]string{
+				"resources.yaml": "password: <redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+			},
+			missing: []string{"resources-kudo.dev.yaml"},
+		},
+		{
+			name: "log tail lines",
+			builder: func(builder ArtifactsBuilder) ArtifactsBuilder {
+				return builder.WithIncludedResources("pods").WithLogTailLines(1).WithConcurrency(1)
+			},
+			expected: map[string]string{
+				"resources.yaml":         "name: kafka-0",
+				"pods/kafka-0/kafka.log": "started\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			sb := strings.Builder{}
+
+			err := test.builder(BuildArtifacts("ns").WithDirectory("/out").WithFs(fs).WithWriter(&sb)).Do(fake.Client)
+			assert.NoError(t, err)
+
+			directories, err := afero.ReadDir(fs, "/out")
+			if !assert.NoError(t, err) || !assert.Len(t, directories, 1) {
+				return
+			}
+
+			assert.True(t, strings.HasPrefix(directories[0].Name(), "ns-"))
+
+			directory := path.Join("/out", directories[0].Name())
+
+			for file, expected := range test.expected {
+				content, err := afero.ReadFile(fs, path.Join(directory, file))
+				if assert.NoError(t, err) {
+					assert.Contains(t, string(content), expected, "file %q has unexpected content", file)
+				}
+			}
+
+			for _, file := range test.missing {
+				exists, err := afero.Exists(fs, path.Join(directory, file))
+				assert.NoError(t, err)
+				assert.False(t, exists, "file %q is not expected to exist", file)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CollectionFailure describes an artifact that couldn't be collected.
+type CollectionFailure struct {
+	// Path of the affected file or directory relative to the artifacts directory, empty if there is none.
+	Path string
+	Err  error
+}
+
+// Error returns a pretty-printed error string.
+func (f CollectionFailure) Error() string {
+	if f.Path == "" {
+		return f.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %v", f.Path, f.Err)
+}
+
+// Unwrap returns the cause of the failure.
+func (f CollectionFailure) Unwrap() error { return f.Err }
+
+// CollectionError is the error returned when some artifacts of a namespace couldn't be collected.
+// Collection continues after failures, so all other artifacts are still available.
+type CollectionError struct {
+	Namespace string
+	Failures  []CollectionFailure
+}
+
+// Error returns a pretty-printed error string.
+func (c CollectionError) Error() string {
+	failures := make([]string, 0, len(c.Failures))
+
+	for _, failure := range c.Failures {
+		failures = append(failures, failure.Error())
+	}
+
+	return fmt.Sprintf(
+		"failed to collect %d artifacts of namespace %s: [%s]",
+		len(c.Failures),
+		c.Namespace,
+		strings.Join(failures, "; "))
+}
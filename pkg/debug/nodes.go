@@ -0,0 +1,54 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"text/tabwriter"
+
+	"github.com/kudobuilder/test-tools/pkg/kubernetes"
+)
+
+// collectNodeConditions stores a summary of the conditions of all nodes in 'nodes.txt' of a directory.
+func (c collector) collectNodeConditions(directory string) error {
+	_, _ = fmt.Fprintf(c.writer, "collecting node conditions for debugging...\n")
+
+	started := c.now()
+
+	nodes, err := kubernetes.ListNodes(c.client)
+	if err != nil {
+		return err
+	}
+
+	if err := c.fs.MkdirAll(directory, 0777); err != nil {
+		return fmt.Errorf("creating %q failed: %v", directory, err)
+	}
+
+	var buffer bytes.Buffer
+
+	w := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "NODE\tCONDITION\tSTATUS\tLAST TRANSITION\tREASON\tMESSAGE\n")
+
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			_, _ = fmt.Fprintf(w, "%s\tUnschedulable\tTrue\t\t\t\n", node.Name)
+		}
+
+		for _, condition := range node.Status.Conditions {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				node.Name,
+				condition.Type,
+				condition.Status,
+				formatTime(condition.LastTransitionTime.Time),
+				condition.Reason,
+				condition.Message)
+		}
+	}
+
+	_ = w.Flush()
+
+	c.writeFile(path.Join(directory, "nodes.txt"), buffer.Bytes(), started)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package debug
+
+import (
+	"time"
+)
+
+const kudoManagerNamespace = "kudo-system"
+
+// CollectConfig is used to configure artifact collection.
+type CollectConfig struct {
+	// Directory is the base directory of the artifacts, $TEST_ARTIFACTS_DIRECTORY by default.
+	Directory string
+	// KubectlPath is the kubectl binary used to fetch resources. If empty, client-go is used.
+	KubectlPath string
+	// Namespaces are collected in addition to the test namespace.
+	Namespaces       []string
+	ClusterResources bool
+	Nodes            bool
+	// IncludeResources limits collection to these resources, e.g. "pods" or "instances.kudo.dev".
+	IncludeResources []string
+	// ExcludeResources are not collected, even if included.
+	ExcludeResources []string
+	// DisableRedaction collects Secret data and the fields selected by RedactPaths unchanged.
+	DisableRedaction bool
+	// RedactPaths are JSONPath expressions selecting fields to redact in addition to Secret data,
+	// e.g. "{.spec.containers[*].env[?(@.name==\"PASSWORD\")].value}" or "{.metadata.annotations['token']}".
+	RedactPaths []string
+	// LogTailLines limits the number of lines collected from the end of each container log.
+	LogTailLines *int64
+	// Timeout of the whole collection, no timeout if zero.
+	Timeout time.Duration
+	// Concurrency limits the number of resource groups fetched at the same time, no limit if zero.
+	Concurrency int
+	// Archive replaces the artifacts directory with a '.tar.gz' file of the same name.
+	Archive bool
+}
+
+// CollectOption changes a CollectConfig.
+type CollectOption func(*CollectConfig)
+
+// CollectClusterResources collects cluster-scoped resources, e.g. nodes, persistent volumes,
+// custom resource definitions and webhook configurations.
+func CollectClusterResources() CollectOption {
+	return func(config *CollectConfig) {
+		config.ClusterResources = true
+	}
+}
+
+// CollectNodes collects a summary of the conditions of all nodes.
+func CollectNodes() CollectOption {
+	return func(config *CollectConfig) {
+		config.Nodes = true
+	}
+}
+
+// CollectKUDOManager collects the resources and logs of the KUDO manager's namespace.
+func CollectKUDOManager() CollectOption {
+	return CollectNamespaces(kudoManagerNamespace)
+}
+
+// CollectNamespaces collects the resources and logs of additional namespaces.
+func CollectNamespaces(namespaces ...string) CollectOption {
+	return func(config *CollectConfig) {
+		config.Namespaces = append(config.Namespaces, namespaces...)
+	}
+}
@@ -0,0 +1,237 @@
+package debug
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// artifactsTimeFormat is used in the name of artifacts directories. Unlike RFC3339, it doesn't contain colons,
+	// which aren't supported by some filesystems and artifact stores.
+	artifactsTimeFormat = "2006-01-02T15-04-05Z"
+	manifestFileName    = "manifest.json"
+	// archivedManifestSuffix is appended to the artifacts directory for the manifest stored next to its archive.
+	archivedManifestSuffix = ".manifest.json"
+)
+
+// Manifest lists the collected artifacts. It is stored as 'manifest.json' in the artifacts directory and,
+// if the artifacts are archived, as '<directory>.manifest.json' next to the archive.
+type Manifest struct {
+	Namespace string          `json:"namespace"`
+	Started   time.Time       `json:"started"`
+	Finished  time.Time       `json:"finished"`
+	Files     []ManifestFile  `json:"files"`
+	Errors    []ManifestError `json:"errors"`
+}
+
+// ManifestFile is a collected file.
+type ManifestFile struct {
+	// Path relative to the artifacts directory.
+	Path string `json:"path"`
+	Size int    `json:"size"`
+	// DurationMilliseconds is the time spent on fetching the content of the file.
+	DurationMilliseconds int64 `json:"durationMilliseconds"`
+}
+
+// ManifestError is an error encountered during collection.
+type ManifestError struct {
+	// Path of the affected file or directory relative to the artifacts directory, if any.
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error"`
+}
+
+// manifestRecorder allows concurrent updates of a manifest and keeps track of the failures of a collection.
+type manifestRecorder struct {
+	mutex    sync.Mutex
+	root     string
+	manifest Manifest
+	failures []CollectionFailure
+}
+
+func newManifestRecorder(namespace, root string, started time.Time) *manifestRecorder {
+	return &manifestRecorder{
+		root: root,
+		manifest: Manifest{
+			Namespace: namespace,
+			Started:   started,
+			Files:     []ManifestFile{},
+			Errors:    []ManifestError{},
+		},
+	}
+}
+
+func (r *manifestRecorder) addFile(filePath string, size int, duration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.manifest.Files = append(r.manifest.Files, ManifestFile{
+		Path:                 r.relative(filePath),
+		Size:                 size,
+		DurationMilliseconds: duration.Milliseconds(),
+	})
+}
+
+func (r *manifestRecorder) addError(filePath string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	failure := CollectionFailure{
+		Path: r.relative(filePath),
+		Err:  err,
+	}
+
+	r.failures = append(r.failures, failure)
+	r.manifest.Errors = append(r.manifest.Errors, ManifestError{
+		Path:  failure.Path,
+		Error: err.Error(),
+	})
+}
+
+// err returns a CollectionError listing all failures, nil if there are none.
+func (r *manifestRecorder) err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.failures) == 0 {
+		return nil
+	}
+
+	return CollectionError{
+		Namespace: r.manifest.Namespace,
+		Failures:  append([]CollectionFailure{}, r.failures...),
+	}
+}
+
+// finish returns the manifest with its files sorted by path.
+func (r *manifestRecorder) finish(finished time.Time) Manifest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.manifest.Finished = finished
+
+	sort.Slice(r.manifest.Files, func(i, j int) bool {
+		return r.manifest.Files[i].Path < r.manifest.Files[j].Path
+	})
+
+	return r.manifest
+}
+
+func (r *manifestRecorder) relative(filePath string) string {
+	if filePath == r.root {
+		return ""
+	}
+
+	return strings.TrimPrefix(filePath, r.root+"/")
+}
+
+// writeManifest stores the manifest in a file, usually 'manifest.json' in the artifacts directory.
+func (c collector) writeManifest(outPath string) error {
+	data, err := json.MarshalIndent(c.manifest.finish(c.now()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest failed: %v", err)
+	}
+
+	if err := c.fs.MkdirAll(path.Dir(outPath), 0777); err != nil {
+		return fmt.Errorf("creating %q failed: %v", path.Dir(outPath), err)
+	}
+
+	if err := afero.WriteFile(c.fs, outPath, data, 0666); err != nil {
+		return fmt.Errorf("writing %q failed: %v", outPath, err)
+	}
+
+	return nil
+}
+
+// archiveDirectory replaces a directory with the gzip-compressed tarball '<directory>.tar.gz'.
+// The paths in the tarball start with the name of the directory.
+func archiveDirectory(fs afero.Fs, directory string) error {
+	archivePath := directory + ".tar.gz"
+
+	file, err := fs.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("creating %q failed: %v", archivePath, err)
+	}
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	var archived []string
+
+	err = afero.Walk(fs, directory, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		archived = append(archived, filePath)
+
+		return archiveFile(fs, tarWriter, filepath.Dir(directory), filePath, info)
+	})
+
+	for _, closer := range []io.Closer{tarWriter, gzipWriter, file} {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("archiving %q failed: %v", directory, err)
+	}
+
+	// Files are removed individually, as RemoveAll of some filesystems also removes the archive sharing the prefix.
+	for i := len(archived) - 1; i >= 0; i-- {
+		if err := fs.Remove(archived[i]); err != nil {
+			return fmt.Errorf("removing %q failed: %v", archived[i], err)
+		}
+	}
+
+	return nil
+}
+
+func archiveFile(fs afero.Fs, tarWriter *tar.Writer, base, filePath string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+
+	name, err := filepath.Rel(base, filePath)
+	if err != nil {
+		return err
+	}
+
+	header.Name = filepath.ToSlash(name)
+
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return nil
+	}
+
+	file, err := fs.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+
+	return err
+}
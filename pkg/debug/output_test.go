@@ -0,0 +1,117 @@
+package debug
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func TestCollectArtifacts_Manifest(t *testing.T) {
+	d := debugDeps{
+		artifactsDirectoryBase: "/artifacts",
+		now:                    func() time.Time { return time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC) },
+	}
+	d.execCommand = getExecCommand(t, testStruct{
+		apiResourcesOut: "pods\ngremlins\ninstances.kudo.dev\n",
+		getOut: map[string]string{
+			"gremlins,pods":      "",
+			"instances.kudo.dev": "kudo\n",
+		},
+		getExit: map[string]int{
+			"gremlins,pods": 1,
+		},
+	})
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	err := d.collectArtifacts(client.Client{KubeConfigPath: "kube.config"}, fs, &sb, "ns", "kubectl")
+	assert.Error(t, err)
+
+	data, err := afero.ReadFile(fs, "/artifacts/ns-2020-10-01T12-00-00Z/manifest.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var manifest Manifest
+
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, Manifest{
+		Namespace: "ns",
+		Started:   time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC),
+		Finished:  time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC),
+		Files: []ManifestFile{
+			{Path: "resources-kudo.dev.yaml", Size: 5},
+		},
+		Errors: []ManifestError{
+			{Path: "resources.yaml", Error: "fetching gremlins,pods failed: exit status 1"},
+		},
+	}, manifest)
+}
+
+func TestCollectArtifacts_Archive(t *testing.T) {
+	fake := client.NewFake(testObject("v1", "Pod", "ns", "kafka-0"))
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	err := debugDeps{
+		artifactsDirectoryBase: "/artifacts",
+		now:                    func() time.Time { return time.Time{} },
+	}.collect(fake.Client, BuildArtifacts("ns").WithFs(fs).WithWriter(&sb).WithArchive())
+	assert.NoError(t, err, sb.String())
+
+	exists, err := afero.DirExists(fs, "/artifacts/ns-0001-01-01T00-00-00Z")
+	assert.NoError(t, err)
+	assert.False(t, exists, "the artifacts directory should be replaced by the archive")
+
+	file, err := fs.Open("/artifacts/ns-0001-01-01T00-00-00Z.tar.gz")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+	contents := map[string]string{}
+
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+
+		content, err := ioutil.ReadAll(tarReader)
+		assert.NoError(t, err)
+
+		contents[header.Name] = string(content)
+	}
+
+	assert.Contains(t, contents, "ns-0001-01-01T00-00-00Z/")
+	assert.Contains(t, contents, "ns-0001-01-01T00-00-00Z/manifest.json")
+	assert.Contains(t, contents["ns-0001-01-01T00-00-00Z/resources.yaml"], "name: kafka-0")
+
+	data, err := afero.ReadFile(fs, "/artifacts/ns-0001-01-01T00-00-00Z.manifest.json")
+	if assert.NoError(t, err, "the manifest should be stored next to the archive") {
+		var manifest Manifest
+
+		assert.NoError(t, json.Unmarshal(data, &manifest))
+		assert.Equal(t, "ns", manifest.Namespace)
+		assert.Empty(t, manifest.Errors)
+		assert.NotEmpty(t, manifest.Files)
+	}
+}
@@ -0,0 +1,186 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kudobuilder/test-tools/pkg/kubernetes"
+)
+
+// collectPodArtifacts saves a describe-style summary and the logs of all containers for every pod in a namespace.
+// The artifacts of a pod are stored in 'pods/<pod>' of the artifacts directory:
+//   describe.txt              status of the pod and its containers, and events involving the pod
+//   <container>.log           logs of the current container
+//   <container>.previous.log  logs of the previous container, if the container has been restarted
+func (c collector) collectPodArtifacts(namespace, artifactsDirectory string) error {
+	_, _ = fmt.Fprintf(c.writer, "collecting pod logs for debugging...\n")
+
+	pods, err := kubernetes.ListPods(c.client, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		podDirectory := path.Join(artifactsDirectory, "pods", pod.Name)
+
+		if err := c.fs.MkdirAll(podDirectory, 0777); err != nil {
+			return fmt.Errorf("creating %q failed: %v", podDirectory, err)
+		}
+
+		describePath := path.Join(podDirectory, "describe.txt")
+		started := c.now()
+
+		events, err := pod.Events()
+		if err != nil {
+			c.report(describePath, fmt.Errorf("fetching events of pod %s failed: %w", pod.Name, err))
+		}
+
+		c.writeFile(describePath, describePod(pod.Pod, events), started)
+
+		statuses := make([]corev1.ContainerStatus, 0,
+			len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			if containerStarted(status) {
+				c.collectContainerLogs(pod, status.Name, path.Join(podDirectory, status.Name+".log"))
+			}
+
+			if status.RestartCount > 0 {
+				c.collectContainerLogs(pod, status.Name, path.Join(podDirectory, status.Name+".previous.log"),
+					kubernetes.LogPrevious())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c collector) collectContainerLogs(
+	pod kubernetes.Pod, container string, outPath string, options ...kubernetes.LogOption) {
+	if c.config.LogTailLines != nil {
+		options = append(options, kubernetes.LogTailLines(*c.config.LogTailLines))
+	}
+
+	started := c.now()
+
+	logs, err := pod.ContainerLogs(container, options...)
+	if err != nil {
+		c.report(outPath, fmt.Errorf("fetching logs of container %s in pod %s failed: %w", container, pod.Name, err))
+		return
+	}
+
+	c.writeFile(outPath, logs, started)
+}
+
+// containerStarted checks if a container has logs, i.e. it is or was running.
+func containerStarted(status corev1.ContainerStatus) bool {
+	return status.State.Running != nil || status.State.Terminated != nil
+}
+
+// describePod summarizes a pod similar to 'kubectl describe pod'.
+func describePod(pod corev1.Pod, events []corev1.Event) []byte {
+	var buffer bytes.Buffer
+
+	w := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", pod.Name)
+	_, _ = fmt.Fprintf(w, "Namespace:\t%s\n", pod.Namespace)
+	_, _ = fmt.Fprintf(w, "Node:\t%s\n", pod.Spec.NodeName)
+	_, _ = fmt.Fprintf(w, "Phase:\t%s\n", pod.Status.Phase)
+
+	if pod.Status.Reason != "" {
+		_, _ = fmt.Fprintf(w, "Reason:\t%s\n", pod.Status.Reason)
+	}
+
+	if pod.Status.Message != "" {
+		_, _ = fmt.Fprintf(w, "Message:\t%s\n", pod.Status.Message)
+	}
+
+	_, _ = fmt.Fprintf(w, "Conditions:\n")
+
+	for _, condition := range pod.Status.Conditions {
+		_, _ = fmt.Fprintf(w, "  %s:\t%s\t%s\n", condition.Type, condition.Status, condition.Message)
+	}
+
+	describeContainers(w, "Init Containers", pod.Status.InitContainerStatuses)
+	describeContainers(w, "Containers", pod.Status.ContainerStatuses)
+
+	_ = w.Flush()
+
+	buffer.WriteString("Events:\n")
+
+	w = tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "  TYPE\tREASON\tFIRST SEEN\tLAST SEEN\tCOUNT\tFROM\tMESSAGE\n")
+
+	for _, event := range events {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			event.Type,
+			event.Reason,
+			formatTime(kubernetes.EventFirstTime(event)),
+			formatTime(kubernetes.EventLastTime(event)),
+			event.Count,
+			event.Source.Component,
+			event.Message)
+	}
+
+	_ = w.Flush()
+
+	return buffer.Bytes()
+}
+
+func describeContainers(w io.Writer, title string, statuses []corev1.ContainerStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s:\n", title)
+
+	for _, status := range statuses {
+		_, _ = fmt.Fprintf(w, "  %s:\n", status.Name)
+		_, _ = fmt.Fprintf(w, "    Image:\t%s\n", status.Image)
+		_, _ = fmt.Fprintf(w, "    State:\t%s\n", strings.TrimSpace(containerState(status.State)))
+
+		if status.LastTerminationState != (corev1.ContainerState{}) {
+			_, _ = fmt.Fprintf(w, "    Last State:\t%s\n", strings.TrimSpace(containerState(status.LastTerminationState)))
+		}
+
+		_, _ = fmt.Fprintf(w, "    Ready:\t%t\n", status.Ready)
+		_, _ = fmt.Fprintf(w, "    Restart Count:\t%d\n", status.RestartCount)
+	}
+}
+
+func containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running since %s", formatTime(state.Running.StartedAt.Time))
+	case state.Waiting != nil:
+		return fmt.Sprintf("Waiting (%s) %s", state.Waiting.Reason, state.Waiting.Message)
+	case state.Terminated != nil:
+		return fmt.Sprintf(
+			"Terminated (%s, exit code %d) at %s %s",
+			state.Terminated.Reason,
+			state.Terminated.ExitCode,
+			formatTime(state.Terminated.FinishedAt.Time),
+			state.Terminated.Message)
+	default:
+		return "Unknown"
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,149 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func TestCollectPodArtifacts(t *testing.T) {
+	started := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-0",
+			Namespace: "ns",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "init",
+					Image: "busybox",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Completed", FinishedAt: metav1.NewTime(started)},
+					},
+				},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "kafka",
+					Image: "kafka",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:     "Error",
+							ExitCode:   1,
+							FinishedAt: metav1.NewTime(started),
+						},
+					},
+					Ready:        true,
+					RestartCount: 1,
+				},
+				{
+					Name:  "sidecar",
+					Image: "sidecar",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+					},
+				},
+			},
+		},
+	}
+
+	event := corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-0.backoff",
+			Namespace: "ns",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "Pod",
+			Name: "kafka-0",
+		},
+		Type:    corev1.EventTypeWarning,
+		Reason:  "BackOff",
+		Message: "Back-off pulling image",
+		Count:   3,
+		Source:  corev1.EventSource{Component: "kubelet"},
+		// Events reported through the events.k8s.io API only set the event time.
+		EventTime: metav1.NewMicroTime(started),
+	}
+
+	fake := client.NewFake(&pod, &event).
+		WithContainerLogs("ns", "kafka-0", "init", []byte("initialized\n")).
+		WithContainerLogs("ns", "kafka-0", "kafka", []byte("started\n")).
+		WithPreviousContainerLogs("ns", "kafka-0", "kafka", []byte("crashed\n"))
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	c := collector{
+		client:   fake.Client,
+		fs:       fs,
+		writer:   &sb,
+		manifest: newManifestRecorder("ns", "/artifacts", time.Time{}),
+		now:      time.Now,
+	}
+
+	err := c.collectPodArtifacts("ns", "/artifacts")
+	assert.NoError(t, err)
+	assert.Equal(t, "collecting pod logs for debugging...\n", sb.String())
+
+	for file, expected := range map[string]string{
+		"/artifacts/pods/kafka-0/init.log":           "initialized\n",
+		"/artifacts/pods/kafka-0/kafka.log":          "started\n",
+		"/artifacts/pods/kafka-0/kafka.previous.log": "crashed\n",
+	} {
+		content, err := afero.ReadFile(fs, file)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, string(content), "file %q has unexpected content", file)
+		}
+	}
+
+	exists, err := afero.Exists(fs, "/artifacts/pods/kafka-0/sidecar.log")
+	assert.NoError(t, err)
+	assert.False(t, exists, "logs of a container that never started should not be collected")
+
+	describe, err := afero.ReadFile(fs, "/artifacts/pods/kafka-0/describe.txt")
+	if assert.NoError(t, err) {
+		assert.Equal(t, `Name:       kafka-0
+Namespace:  ns
+Node:       node-1
+Phase:      Running
+Conditions:
+Init Containers:
+  init:
+    Image:          busybox
+    State:          Terminated (Completed, exit code 0) at 2020-10-01T12:00:00Z
+    Ready:          false
+    Restart Count:  0
+Containers:
+  kafka:
+    Image:          kafka
+    State:          Running since 2020-10-01T12:00:00Z
+    Last State:     Terminated (Error, exit code 1) at 2020-10-01T12:00:00Z
+    Ready:          true
+    Restart Count:  1
+  sidecar:
+    Image:          sidecar
+    State:          Waiting (ImagePullBackOff)
+    Ready:          false
+    Restart Count:  0
+Events:
+  TYPE     REASON   FIRST SEEN            LAST SEEN             COUNT  FROM     MESSAGE
+  Warning  BackOff  2020-10-01T12:00:00Z  2020-10-01T12:00:00Z  3      kubelet  Back-off pulling image
+`, string(describe))
+	}
+}
@@ -0,0 +1,219 @@
+package debug
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	redactionKeyLength                 = 32
+)
+
+// The hashes of redacted values are keyed with a random key, created once per process. Plain hashes of short or
+// guessable values, e.g. passwords, could be reversed by brute force. With the key, values can still be compared
+// within a run, e.g. between snapshots, but not with values outside of it.
+var (
+	redactionKey     []byte
+	redactionKeyErr  error
+	redactionKeyOnce sync.Once
+)
+
+// redactor replaces sensitive values in collected resources.
+// The data of Secrets is always redacted, other fields can be selected with JSONPath expressions.
+type redactor struct {
+	paths []redactedPath
+	key   []byte
+}
+
+// redactedPath selects fields of the objects found by a JSONPath expression. An empty field selects all fields.
+type redactedPath struct {
+	parent *jsonpath.JSONPath
+	field  string
+}
+
+func newRedactor(expressions []string) (*redactor, error) {
+	// Splits an expression into the path of the parent object and the redacted field,
+	// which is either a '.field', a ['field'], or a wildcard for all fields.
+	redactedFieldPattern := regexp.MustCompile(`^(.*?)(?:\.([^.\[\]]+)|\[['"]([^'"]+)['"]\]|\[\*\])$`)
+
+	redactionKeyOnce.Do(func() {
+		redactionKey = make([]byte, redactionKeyLength)
+		_, redactionKeyErr = rand.Read(redactionKey)
+	})
+
+	if redactionKeyErr != nil {
+		return nil, fmt.Errorf("failed to create redaction key: %w", redactionKeyErr)
+	}
+
+	r := &redactor{key: redactionKey}
+
+	for _, expression := range expressions {
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(expression), "{"), "}")
+
+		match := redactedFieldPattern.FindStringSubmatch(trimmed)
+		if match == nil {
+			return nil, fmt.Errorf("redaction path %q doesn't end with a field", expression)
+		}
+
+		var parent *jsonpath.JSONPath
+
+		if match[1] != "" {
+			parent = jsonpath.New("redact").AllowMissingKeys(true)
+
+			if err := parent.Parse(fmt.Sprintf("{%s}", match[1])); err != nil {
+				return nil, fmt.Errorf("invalid redaction path %q: %w", expression, err)
+			}
+		}
+
+		field := match[2] + match[3]
+		if field == "*" {
+			field = ""
+		}
+
+		r.paths = append(r.paths, redactedPath{parent: parent, field: field})
+	}
+
+	return r, nil
+}
+
+// redact replaces the sensitive values of all objects in a YAML list or object.
+// Redaction fails closed: data that can't be parsed, e.g. the truncated output of a failed fetch, and objects
+// without a kind, which can't be recognized as Secrets, return an error instead of unredacted data.
+// Data that is valid YAML, but not an object, e.g. the names printed by 'kubectl get -o name', is returned unchanged.
+func (r *redactor) redact(data []byte) ([]byte, error) {
+	var parsed interface{}
+
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse resources for redaction: %w", err)
+	}
+
+	object, ok := parsed.(map[string]interface{})
+	if !ok {
+		return data, nil
+	}
+
+	objects := []interface{}{object}
+
+	if items, ok := object["items"].([]interface{}); ok {
+		objects = items
+	}
+
+	for i, item := range objects {
+		itemObject, ok := item.(map[string]interface{})
+		if !ok || itemObject["kind"] == nil {
+			return nil, fmt.Errorf("failed to redact object %d: object has no kind", i)
+		}
+
+		r.redactObject(itemObject)
+	}
+
+	redactedData, err := yaml.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize redacted resources: %w", err)
+	}
+
+	return redactedData, nil
+}
+
+func (r *redactor) redactObject(object map[string]interface{}) {
+	if object["kind"] == "Secret" {
+		r.redactSecret(object)
+	}
+
+	for _, path := range r.paths {
+		for _, parent := range path.parents(object) {
+			for key, value := range parent {
+				if path.field == "" || path.field == key {
+					parent[key] = r.redactedValue(value)
+				}
+			}
+		}
+	}
+}
+
+// parents returns the objects containing the redacted fields.
+func (p redactedPath) parents(object map[string]interface{}) []map[string]interface{} {
+	if p.parent == nil {
+		return []map[string]interface{}{object}
+	}
+
+	results, err := p.parent.FindResults(object)
+	if err != nil {
+		return nil
+	}
+
+	var parents []map[string]interface{}
+
+	for _, result := range results {
+		for _, value := range result {
+			if parent, ok := value.Interface().(map[string]interface{}); ok {
+				parents = append(parents, parent)
+			}
+		}
+	}
+
+	return parents
+}
+
+// redactSecret replaces the data of a Secret, including the copy kubectl keeps in an annotation.
+func (r *redactor) redactSecret(secret map[string]interface{}) {
+	if data, ok := secret["data"].(map[string]interface{}); ok {
+		for key, value := range data {
+			// Hash the decoded data, so that it can be compared with the same value in other fields, e.g. stringData.
+			if encoded, ok := value.(string); ok {
+				if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+					data[key] = r.redactedBytes(decoded)
+					continue
+				}
+			}
+
+			data[key] = r.redactedValue(value)
+		}
+	}
+
+	if stringData, ok := secret["stringData"].(map[string]interface{}); ok {
+		for key, value := range stringData {
+			stringData[key] = r.redactedValue(value)
+		}
+	}
+
+	if metadata, ok := secret["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			if value, ok := annotations[lastAppliedConfigurationAnnotation]; ok {
+				annotations[lastAppliedConfigurationAnnotation] = r.redactedValue(value)
+			}
+		}
+	}
+}
+
+// redactedValue replaces a value by its keyed hash and length, other values than strings are hashed as JSON.
+func (r *redactor) redactedValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return r.redactedBytes([]byte(s))
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "<redacted>"
+	}
+
+	return r.redactedBytes(data)
+}
+
+func (r *redactor) redactedBytes(data []byte) string {
+	mac := hmac.New(sha256.New, r.key)
+	_, _ = mac.Write(data)
+
+	return fmt.Sprintf("<redacted hmac-sha256:%x length:%d>", mac.Sum(nil)[:8], len(data))
+}
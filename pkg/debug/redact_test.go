@@ -0,0 +1,164 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// A fixed key makes the hashes of redacted values predictable in tests.
+	redactionKeyOnce.Do(func() { redactionKey = []byte("test") })
+}
+
+const redactedList = `apiVersion: v1
+items:
+- apiVersion: v1
+  data:
+    password: c2VjcmV0
+  kind: Secret
+  metadata:
+    annotations:
+      kubectl.kubernetes.io/last-applied-configuration: '{"data":{"password":"c2VjcmV0"}}'
+    name: credentials
+  stringData:
+    token: secret
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    annotations:
+      example.com/token: secret
+    name: kafka-0
+  spec:
+    containers:
+    - env:
+      - name: PASSWORD
+        value: secret
+      - name: USER
+        value: kafka
+kind: List
+`
+
+func TestRedactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    []string
+		expected []string
+		kept     []string
+	}{
+		{
+			name: "secrets only",
+			expected: []string{
+				"password: <redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+				"token: <redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+				"kubectl.kubernetes.io/last-applied-configuration: <redacted hmac-sha256:",
+			},
+			kept: []string{
+				"example.com/token: secret",
+				"value: secret",
+			},
+		},
+		{
+			name: "JSONPath expressions",
+			paths: []string{
+				`{.spec.containers[*].env[?(@.name=="PASSWORD")].value}`,
+				`{.metadata.annotations['example.com/token']}`,
+			},
+			expected: []string{
+				"example.com/token: <redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+				"value: <redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+			},
+			kept: []string{
+				"name: PASSWORD",
+				"value: kafka",
+			},
+		},
+		{
+			name:  "wildcard",
+			paths: []string{".metadata.annotations.*"},
+			expected: []string{
+				"example.com/token: <redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+			},
+			kept: []string{
+				"name: kafka-0",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			r, err := newRedactor(test.paths)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			data, err := r.redact([]byte(redactedList))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			redacted := string(data)
+
+			for _, expected := range test.expected {
+				assert.Contains(t, redacted, expected)
+			}
+
+			for _, kept := range test.kept {
+				assert.Contains(t, redacted, kept)
+			}
+
+			assert.NotContains(t, redacted, "c2VjcmV0")
+		})
+	}
+}
+
+func TestRedactorKey(t *testing.T) {
+	r, err := newRedactor(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, r.redactedValue("secret"), r.redactedValue("secret"), "values should be comparable within a run")
+
+	otherRun := &redactor{key: []byte("other")}
+	assert.NotEqual(t, r.redactedValue("secret"), otherRun.redactedValue("secret"))
+
+	// The plain SHA-256 of the value mustn't show up in the artifacts.
+	assert.NotContains(t, r.redactedValue("secret"), "2bb80d537b1da3e3")
+}
+
+func TestRedactorInvalidPath(t *testing.T) {
+	_, err := newRedactor([]string{"{.spec.containers[?(@.name=='x']}"})
+	assert.Error(t, err)
+
+	_, err = newRedactor([]string{"{.items[0]}"})
+	assert.EqualError(t, err, "redaction path \"{.items[0]}\" doesn't end with a field")
+}
+
+func TestRedactorKeepsOtherData(t *testing.T) {
+	r, err := newRedactor(nil)
+	assert.NoError(t, err)
+
+	for _, data := range []string{"pod1\n", "peek..."} {
+		redacted, err := r.redact([]byte(data))
+		assert.NoError(t, err)
+		assert.Equal(t, data, string(redacted))
+	}
+}
+
+func TestRedactorTruncatedSecret(t *testing.T) {
+	r, err := newRedactor(nil)
+	assert.NoError(t, err)
+
+	// Output of a failed fetch, ending before the kind of the Secret.
+	truncated := redactedList[:strings.Index(redactedList, "  kind: Secret")]
+
+	for _, data := range []string{truncated, truncated[:len(truncated)-3], "items:\n- data: {password: c2Vj"} {
+		redacted, err := r.redact([]byte(data))
+		assert.Error(t, err, "redaction of %q should fail", data)
+		assert.Nil(t, redacted)
+	}
+}
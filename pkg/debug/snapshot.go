@@ -0,0 +1,334 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+// SnapshotBuilder tracks the options set for taking a snapshot of the resources of a namespace.
+type SnapshotBuilder struct {
+	Namespace        string
+	KubectlPath      string
+	IncludeResources []string
+	ExcludeResources []string
+	DisableRedaction bool
+}
+
+// Snapshot is the state of all resources of a namespace at a point in time.
+// Objects are normalized: their resource version, managed fields and timestamps other than the deletion timestamp
+// are removed, so that only changes of their state show up in a diff.
+type Snapshot struct {
+	Namespace string
+	// Objects are keyed by their kind, group and name, e.g. "Pod/kafka-0" or "Instance.kudo.dev/kafka".
+	Objects map[string]*unstructured.Unstructured
+}
+
+// SnapshotDiff lists the objects that differ between two snapshots.
+type SnapshotDiff struct {
+	Added   []string
+	Removed []string
+	// Changed maps the keys of changed objects to a unified diff of their YAML.
+	Changed map[string]string
+}
+
+// BuildSnapshot creates a builder for a snapshot of all listable resources of a namespace.
+// Events are excluded by default, as they aren't part of the state of the namespace. Secret data is redacted.
+// For example, to find out which objects an upgrade changed:
+//   before, err := debug.BuildSnapshot(namespace).Do(client)
+//   if err != nil ...
+//   // upgrade the operator
+//   after, err := debug.BuildSnapshot(namespace).Do(client)
+//   if err != nil ...
+//   diff, err := before.Diff(after)
+//   if err != nil ...
+//   fmt.Print(diff)
+func BuildSnapshot(namespace string) SnapshotBuilder {
+	return SnapshotBuilder{
+		Namespace:        namespace,
+		ExcludeResources: []string{"events", "events.events.k8s.io"},
+	}
+}
+
+// WithKubectl fetches resources with a kubectl binary instead of client-go.
+// This requires a client with a kubeconfig file.
+func (builder SnapshotBuilder) WithKubectl(kubectlPath string) SnapshotBuilder {
+	builder.KubectlPath = kubectlPath
+	return builder
+}
+
+// WithIncludedResources only includes these resources in the snapshot, e.g. "configmaps" or "instances.kudo.dev".
+func (builder SnapshotBuilder) WithIncludedResources(resources ...string) SnapshotBuilder {
+	builder.IncludeResources = append(append([]string{}, builder.IncludeResources...), resources...)
+	return builder
+}
+
+// WithExcludedResources doesn't include these resources in the snapshot, in addition to events.
+func (builder SnapshotBuilder) WithExcludedResources(resources ...string) SnapshotBuilder {
+	builder.ExcludeResources = append(append([]string{}, builder.ExcludeResources...), resources...)
+	return builder
+}
+
+// WithoutRedaction keeps Secret data unchanged.
+func (builder SnapshotBuilder) WithoutRedaction() SnapshotBuilder {
+	builder.DisableRedaction = true
+	return builder
+}
+
+// Do takes the snapshot.
+// Resources that fail to be fetched are left out: the snapshot contains all other resources,
+// and the returned error lists the failing ones.
+func (builder SnapshotBuilder) Do(client client.Client) (Snapshot, error) {
+	ctx := client.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return builder.take(debugDeps{execCommand: exec.Command}.backend(ctx, client, ioutil.Discard, builder.KubectlPath))
+}
+
+func (builder SnapshotBuilder) take(backend backend) (Snapshot, error) {
+	var redactor *redactor
+
+	if !builder.DisableRedaction {
+		var err error
+
+		if redactor, err = newRedactor(nil); err != nil {
+			return Snapshot{}, err
+		}
+	}
+
+	resources, err := backend.listableResources(true)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to fetch API resource types for snapshot of namespace %s: %w",
+			builder.Namespace, err)
+	}
+
+	snapshot := Snapshot{
+		Namespace: builder.Namespace,
+		Objects:   make(map[string]*unstructured.Unstructured),
+	}
+
+	selected := func(resource schema.GroupVersionResource) bool {
+		return resourceSelected(resource, builder.IncludeResources, builder.ExcludeResources)
+	}
+
+	var errs []error
+
+	for _, resources := range groupResources(resources, selected) {
+		var output bytes.Buffer
+
+		// Resources that could be listed are still written when listing others of the group fails.
+		if err := backend.writeResources(builder.Namespace, resources, &output); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch %s in namespace %s: %w",
+				resourceNames(resources), builder.Namespace, err))
+
+			if output.Len() == 0 {
+				continue
+			}
+		}
+
+		data := output.Bytes()
+
+		if redactor != nil {
+			var err error
+
+			if data, err = redactor.redact(data); err != nil {
+				errs = append(errs, fmt.Errorf("failed to redact %s in namespace %s: %w",
+					resourceNames(resources), builder.Namespace, err))
+				continue
+			}
+		}
+
+		if err := snapshot.add(data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse %s in namespace %s: %w",
+				resourceNames(resources), builder.Namespace, err))
+		}
+	}
+
+	return snapshot, utilerrors.NewAggregate(errs)
+}
+
+// add normalizes and adds the objects of a YAML list.
+func (s Snapshot) add(data []byte) error {
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		normalize(item)
+
+		object := &unstructured.Unstructured{Object: item}
+		key := fmt.Sprintf("%s/%s", object.GroupVersionKind().GroupKind(), object.GetName())
+
+		s.Objects[key] = object
+	}
+
+	return nil
+}
+
+// Keys returns the sorted keys of all objects in the snapshot.
+func (s Snapshot) Keys() []string {
+	keys := make([]string, 0, len(s.Objects))
+
+	for key := range s.Objects {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Diff compares the snapshot to a later snapshot.
+func (s Snapshot) Diff(after Snapshot) (SnapshotDiff, error) {
+	diff := SnapshotDiff{
+		Changed: make(map[string]string),
+	}
+
+	for _, key := range s.Keys() {
+		afterObject, ok := after.Objects[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, key)
+			continue
+		}
+
+		objectDiff, err := diffObjects(s.Objects[key], afterObject)
+		if err != nil {
+			return SnapshotDiff{}, fmt.Errorf("failed to compare %s: %w", key, err)
+		}
+
+		if objectDiff != "" {
+			diff.Changed[key] = objectDiff
+		}
+	}
+
+	for _, key := range after.Keys() {
+		if _, ok := s.Objects[key]; !ok {
+			diff.Added = append(diff.Added, key)
+		}
+	}
+
+	return diff, nil
+}
+
+// Empty checks if the snapshots are equal.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String returns a readable diff: added, removed and changed objects prefixed with '+', '-' and '~',
+// each changed object followed by the diff of its YAML.
+func (d SnapshotDiff) String() string {
+	var builder strings.Builder
+
+	for _, key := range d.Added {
+		_, _ = fmt.Fprintf(&builder, "+ %s\n", key)
+	}
+
+	for _, key := range d.Removed {
+		_, _ = fmt.Fprintf(&builder, "- %s\n", key)
+	}
+
+	changed := make([]string, 0, len(d.Changed))
+
+	for key := range d.Changed {
+		changed = append(changed, key)
+	}
+
+	sort.Strings(changed)
+
+	for _, key := range changed {
+		_, _ = fmt.Fprintf(&builder, "~ %s\n", key)
+
+		for _, line := range strings.SplitAfter(d.Changed[key], "\n") {
+			if line != "" {
+				_, _ = fmt.Fprintf(&builder, "    %s", line)
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// diffObjects returns a unified diff of the YAML of two objects, an empty string if they are equal.
+func diffObjects(before, after *unstructured.Unstructured) (string, error) {
+	beforeYAML, err := yaml.Marshal(before.Object)
+	if err != nil {
+		return "", err
+	}
+
+	afterYAML, err := yaml.Marshal(after.Object)
+	if err != nil {
+		return "", err
+	}
+
+	if bytes.Equal(beforeYAML, afterYAML) {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		// SplitLines adds an empty line to text ending with a line break.
+		A:        difflib.SplitLines(strings.TrimSuffix(string(beforeYAML), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(afterYAML), "\n")),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  2,
+	})
+}
+
+// normalize removes the fields of an object which change without a change of its state:
+// the resource version, managed fields, and all string fields named like timestamps,
+// e.g. 'creationTimestamp', 'lastTransitionTime' or 'startedAt'.
+// The deletion timestamp is kept, as it marks an object which is being deleted.
+func normalize(object map[string]interface{}) {
+	unstructured.RemoveNestedField(object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(object, "metadata", "managedFields")
+
+	removeTimestamps(object)
+}
+
+func removeTimestamps(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, ok := field.(string); ok && timestampField(key) {
+				delete(value, key)
+				continue
+			}
+
+			removeTimestamps(field)
+		}
+	case []interface{}:
+		for _, item := range value {
+			removeTimestamps(item)
+		}
+	}
+}
+
+func timestampField(name string) bool {
+	if name == "deletionTimestamp" {
+		return false
+	}
+
+	return strings.HasSuffix(name, "Timestamp") ||
+		strings.HasSuffix(name, "Time") ||
+		name == "startedAt" ||
+		name == "finishedAt"
+}
@@ -0,0 +1,117 @@
+package debug
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func snapshotObjects(objects ...runtime.Object) *client.Fake {
+	fake := client.NewFake(objects...)
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	return fake
+}
+
+func TestSnapshot(t *testing.T) {
+	pod := testObject("v1", "Pod", "ns", "kafka-0")
+	pod.Object["metadata"].(map[string]interface{})["resourceVersion"] = "1"
+	pod.Object["metadata"].(map[string]interface{})["creationTimestamp"] = "2020-10-01T12:00:00Z"
+	pod.Object["metadata"].(map[string]interface{})["deletionTimestamp"] = "2020-10-01T12:05:00Z"
+	pod.Object["status"] = map[string]interface{}{
+		"phase": "Running",
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"type":               "Ready",
+				"status":             "True",
+				"lastTransitionTime": "2020-10-01T12:00:00Z",
+			},
+		},
+	}
+
+	secret := testObject("v1", "Secret", "ns", "credentials")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+
+	before, err := BuildSnapshot("ns").Do(snapshotObjects(
+		pod,
+		secret,
+		testObject("v1", "Pod", "other", "zookeeper-0"),
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"),
+	).Client)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"Instance.kudo.dev/kafka", "Pod/kafka-0", "Secret/credentials"}, before.Keys())
+	assert.Equal(t, map[string]interface{}{
+		"name":              "kafka-0",
+		"namespace":         "ns",
+		"deletionTimestamp": "2020-10-01T12:05:00Z",
+	}, before.Objects["Pod/kafka-0"].Object["metadata"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, before.Objects["Pod/kafka-0"].Object["status"].(map[string]interface{})["conditions"])
+	assert.Equal(t, map[string]interface{}{
+		"password": "<redacted hmac-sha256:7fb147aac86a78b6 length:6>",
+	}, before.Objects["Secret/credentials"].Object["data"])
+
+	changedPod := pod.DeepCopy()
+	changedPod.Object["metadata"].(map[string]interface{})["resourceVersion"] = "2"
+	changedPod.Object["status"].(map[string]interface{})["phase"] = "Failed"
+
+	after, err := BuildSnapshot("ns").Do(snapshotObjects(
+		changedPod,
+		testObject("v1", "Service", "ns", "kafka-svc"),
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"),
+	).Client)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	diff, err := before.Diff(after)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, diff.Empty())
+	assert.Equal(t, `+ Service/kafka-svc
+- Secret/credentials
+~ Pod/kafka-0
+    --- before
+    +++ after
+    @@ -9,3 +9,3 @@
+       - status: "True"
+         type: Ready
+    -  phase: Running
+    +  phase: Failed
+`, diff.String())
+
+	diff, err = before.Diff(before)
+	assert.NoError(t, err)
+	assert.True(t, diff.Empty())
+}
+
+func TestSnapshot_IncludedResources(t *testing.T) {
+	snapshot, err := BuildSnapshot("ns").WithIncludedResources("instances.kudo.dev").Do(snapshotObjects(
+		testObject("v1", "Pod", "ns", "kafka-0"),
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"),
+	).Client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Instance.kudo.dev/kafka"}, snapshot.Keys())
+}
+
+func TestSnapshot_FetchFailure(t *testing.T) {
+	fake := snapshotObjects(
+		testObject("v1", "Pod", "ns", "kafka-0"),
+		testObject("kudo.dev/v1beta1", "Instance", "ns", "kafka"))
+	fake.FailOn("list", "services", errors.New("forbidden"))
+
+	snapshot, err := BuildSnapshot("ns").Do(fake.Client)
+	assert.EqualError(t, err,
+		"failed to fetch pods,secrets,services in namespace ns: failed to list services: forbidden")
+	assert.Equal(t, []string{"Instance.kudo.dev/kafka", "Pod/kafka-0"}, snapshot.Keys())
+}
@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+const maxDirectoryNameLength = 100
+
+// TestingT is the subset of testing.TB used to collect artifacts after a test.
+type TestingT interface {
+	Cleanup(func())
+	Failed() bool
+	Name() string
+	Logf(format string, args ...interface{})
+}
+
+// DoOnFailure collects the artifacts once a test and all its subtests completed, if the test failed.
+// The artifacts are stored in a subdirectory of the base directory named after the test.
+//   debug.BuildArtifacts(namespace).WithKUDOManager().DoOnFailure(t, client)
+func (builder ArtifactsBuilder) DoOnFailure(t TestingT, client client.Client) {
+	t.Cleanup(func() {
+		if err := builder.DoForTest(client, t.Name(), t.Failed()); err != nil {
+			t.Logf("%v", err)
+		}
+	})
+}
+
+// AfterEachTest returns a hook for test frameworks like Ginkgo, which collects the artifacts of the current test
+// if it failed. The test function returns the name of the current test and whether it failed.
+// Errors are reported to the writer of the builder and don't fail the test a second time.
+// The builder is fixed when the hook is created, so the namespace needs to be known by then, e.g.
+//   AfterEach(debug.BuildArtifacts(namespace).WithWriter(GinkgoWriter).AfterEachTest(client, func() (string, bool) {
+//   	test := CurrentGinkgoTestDescription()
+//   	return test.FullTestText, test.Failed
+//   }))
+func (builder ArtifactsBuilder) AfterEachTest(client client.Client, test func() (name string, failed bool)) func() {
+	return func() {
+		name, failed := test()
+		_ = builder.DoForTest(client, name, failed)
+	}
+}
+
+// DoForTest collects the artifacts of a test if it failed.
+// The artifacts are stored in a subdirectory of the base directory named after the test.
+func (builder ArtifactsBuilder) DoForTest(client client.Client, testName string, failed bool) error {
+	if !failed {
+		return nil
+	}
+
+	if builder.Directory != "" {
+		builder.Directory = path.Join(builder.Directory, directoryName(testName))
+	}
+
+	return builder.Do(client)
+}
+
+// directoryName converts the name of a test or a cluster into a directory name.
+// Characters other than letters, digits and dashes are replaced, so that subtests, Ginkgo descriptions and
+// kubeconfig contexts like EKS cluster ARNs don't result in nested directories or names that aren't supported
+// by some artifact stores.
+func directoryName(name string) string {
+	safeName := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+
+		return '_'
+	}, name)
+
+	// Long names are truncated. A hash of the full name keeps names that only differ after the truncation apart.
+	if len(safeName) > maxDirectoryNameLength {
+		sum := sha256.Sum256([]byte(name))
+		suffix := fmt.Sprintf("-%x", sum[:4])
+		safeName = safeName[:maxDirectoryNameLength-len(suffix)] + suffix
+	}
+
+	return safeName
+}
@@ -0,0 +1,110 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+type fakeT struct {
+	name     string
+	failed   bool
+	cleanups []func()
+	logs     []string
+}
+
+func (t *fakeT) Cleanup(f func()) { t.cleanups = append(t.cleanups, f) }
+
+func (t *fakeT) Failed() bool { return t.failed }
+
+func (t *fakeT) Name() string { return t.name }
+
+func (t *fakeT) Logf(format string, args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestDoOnFailure(t *testing.T) {
+	fake := client.NewFake(testObject("v1", "Pod", "ns", "kafka-0"))
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	for _, failed := range []bool{false, true} {
+		fs := afero.NewMemMapFs()
+		sb := strings.Builder{}
+		test := &fakeT{name: "TestKafka/upgrade to 2.7", failed: failed}
+
+		BuildArtifacts("ns").WithDirectory("/out").WithFs(fs).WithWriter(&sb).DoOnFailure(test, fake.Client)
+
+		exists, err := afero.Exists(fs, "/out")
+		assert.NoError(t, err)
+		assert.False(t, exists, "artifacts should be collected once the test completed")
+
+		test.finish()
+
+		directories, err := afero.ReadDir(fs, "/out/TestKafka_upgrade_to_2_7")
+		if !failed {
+			assert.Error(t, err, "artifacts of a successful test should not be collected")
+			continue
+		}
+
+		if assert.NoError(t, err) && assert.Len(t, directories, 1) {
+			assert.True(t, strings.HasPrefix(directories[0].Name(), "ns-"))
+		}
+
+		assert.Empty(t, test.logs)
+	}
+}
+
+func TestAfterEachTest(t *testing.T) {
+	fake := client.NewFake(testObject("v1", "Pod", "ns", "kafka-0"))
+	fake.FakeKubernetes.Resources = testDiscovery()
+
+	fs := afero.NewMemMapFs()
+	sb := strings.Builder{}
+
+	name, failed := "Kafka upgrade to 2.7", false
+	hook := BuildArtifacts("ns").WithDirectory("/out").WithFs(fs).WithWriter(&sb).AfterEachTest(
+		fake.Client, func() (string, bool) { return name, failed })
+
+	hook()
+
+	exists, err := afero.Exists(fs, "/out")
+	assert.NoError(t, err)
+	assert.False(t, exists, "artifacts of a successful test should not be collected")
+
+	failed = true
+
+	hook()
+
+	directories, err := afero.ReadDir(fs, "/out/Kafka_upgrade_to_2_7")
+	if assert.NoError(t, err) && assert.Len(t, directories, 1) {
+		assert.True(t, strings.HasPrefix(directories[0].Name(), "ns-"))
+	}
+}
+
+func TestDoForTest_NoDirectory(t *testing.T) {
+	sb := strings.Builder{}
+
+	err := BuildArtifacts("ns").WithDirectory("").WithWriter(&sb).DoForTest(client.Client{}, "Kafka upgrade", true)
+	assert.EqualError(t, err, "$TEST_ARTIFACTS_DIRECTORY not set")
+}
+
+func TestTestDirectoryName(t *testing.T) {
+	assert.Equal(t, "TestKafka_upgrade_to_2_7", directoryName("TestKafka/upgrade to 2.7"))
+	assert.Equal(t, "__", directoryName(".."))
+	long := directoryName(strings.Repeat("a", 200) + "1")
+	assert.Len(t, long, maxDirectoryNameLength)
+	assert.True(t, strings.HasPrefix(long, strings.Repeat("a", 90)))
+	assert.NotEqual(t, long, directoryName(strings.Repeat("a", 200)+"2"),
+		"names that only differ after the truncation should not collide")
+}
@@ -4,9 +4,14 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +33,14 @@ func NewClusterRole(client client.Client, clusterrole rbacv1.ClusterRole) (Clust
 		return ClusterRole{}, fmt.Errorf("failed to create clusterrole %s: %w", clusterrole.Name, err)
 	}
 
-	return ClusterRole{
+	result := ClusterRole{
 		ClusterRole: *createdClusterRole,
-		client: client,
-	}, nil
+		client:      client,
+	}
+
+	client.Track(fmt.Sprintf("clusterrole %s", result.Name), result.Delete)
+
+	return result, nil
 }
 
 // GetClusterRole gets a clusterrole.
@@ -48,7 +57,7 @@ func GetClusterRole(client client.Client, name string) (ClusterRole, error) {
 
 	return ClusterRole{
 		ClusterRole: *clusterrole,
-		client: client,
+		client:      client,
 	}, nil
 }
 
@@ -69,13 +78,96 @@ func ListClusterRoles(client client.Client) ([]ClusterRole, error) {
 	for _, item := range list.Items {
 		clusterroles = append(clusterroles, ClusterRole{
 			ClusterRole: item,
-			client: client,
+			client:      client,
 		})
 	}
 
 	return clusterroles, nil
 }
 
+// ClusterRoleEvent is an event received while watching clusterroles.
+type ClusterRoleEvent struct {
+	Type        watch.EventType
+	ClusterRole ClusterRole
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchClusterRoles watches clusterroles.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchClusterRoles(client client.Client, options metav1.ListOptions) (<-chan ClusterRoleEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		RbacV1().
+		ClusterRoles().
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch clusterroles: %w", err)
+	}
+
+	events := make(chan ClusterRoleEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := ClusterRoleEvent{Type: event.Type}
+
+			object, ok := event.Object.(*rbacv1.ClusterRole)
+			if ok {
+				result.ClusterRole = ClusterRole{
+					ClusterRole: *object,
+					client:      client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch clusterroles: %w", apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the ClusterRole, ordered by their first occurrence.
+// Events of an earlier clusterrole with the same name are not included.
+func (clusterrole ClusterRole) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		clusterrole.client,
+		metav1.NamespaceAll,
+		EventInvolvedObject("ClusterRole", clusterrole.Name),
+		EventInvolvedUID(clusterrole.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a ClusterRole from the Kubernetes cluster.
 func (clusterrole ClusterRole) Delete() error {
 	options := metav1.DeleteOptions{}
@@ -4,9 +4,14 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +33,14 @@ func NewClusterRoleBinding(client client.Client, clusterrolebinding rbacv1.Clust
 		return ClusterRoleBinding{}, fmt.Errorf("failed to create clusterrolebinding %s: %w", clusterrolebinding.Name, err)
 	}
 
-	return ClusterRoleBinding{
+	result := ClusterRoleBinding{
 		ClusterRoleBinding: *createdClusterRoleBinding,
-		client: client,
-	}, nil
+		client:             client,
+	}
+
+	client.Track(fmt.Sprintf("clusterrolebinding %s", result.Name), result.Delete)
+
+	return result, nil
 }
 
 // GetClusterRoleBinding gets a clusterrolebinding.
@@ -48,7 +57,7 @@ func GetClusterRoleBinding(client client.Client, name string) (ClusterRoleBindin
 
 	return ClusterRoleBinding{
 		ClusterRoleBinding: *clusterrolebinding,
-		client: client,
+		client:             client,
 	}, nil
 }
 
@@ -69,13 +78,96 @@ func ListClusterRoleBindings(client client.Client) ([]ClusterRoleBinding, error)
 	for _, item := range list.Items {
 		clusterrolebindings = append(clusterrolebindings, ClusterRoleBinding{
 			ClusterRoleBinding: item,
-			client: client,
+			client:             client,
 		})
 	}
 
 	return clusterrolebindings, nil
 }
 
+// ClusterRoleBindingEvent is an event received while watching clusterrolebindings.
+type ClusterRoleBindingEvent struct {
+	Type               watch.EventType
+	ClusterRoleBinding ClusterRoleBinding
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchClusterRoleBindings watches clusterrolebindings.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchClusterRoleBindings(client client.Client, options metav1.ListOptions) (<-chan ClusterRoleBindingEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		RbacV1().
+		ClusterRoleBindings().
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch clusterrolebindings: %w", err)
+	}
+
+	events := make(chan ClusterRoleBindingEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := ClusterRoleBindingEvent{Type: event.Type}
+
+			object, ok := event.Object.(*rbacv1.ClusterRoleBinding)
+			if ok {
+				result.ClusterRoleBinding = ClusterRoleBinding{
+					ClusterRoleBinding: *object,
+					client:             client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch clusterrolebindings: %w", apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the ClusterRoleBinding, ordered by their first occurrence.
+// Events of an earlier clusterrolebinding with the same name are not included.
+func (clusterrolebinding ClusterRoleBinding) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		clusterrolebinding.client,
+		metav1.NamespaceAll,
+		EventInvolvedObject("ClusterRoleBinding", clusterrolebinding.Name),
+		EventInvolvedUID(clusterrolebinding.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a ClusterRoleBinding from the Kubernetes cluster.
 func (clusterrolebinding ClusterRoleBinding) Delete() error {
 	options := metav1.DeleteOptions{}
@@ -0,0 +1,291 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/kudobuilder/test-tools/pkg/cmd"
+)
+
+// CopyConfig is used to configure copies from and to containers.
+type CopyConfig struct {
+	Progress func(file string, size int64)
+}
+
+// CopyTo copies a file or directory to a pod's container.
+// Directories are copied recursively, file permissions are preserved. The container needs to provide 'tar'.
+//   err := pod.CopyTo("kafka", afero.NewOsFs(), "testdata/server.properties", "/opt/kafka/config/server.properties")
+func (pod Pod) CopyTo(
+	container string,
+	fs afero.Fs,
+	localPath string,
+	remotePath string,
+	options ...CopyOption) error {
+	config := newCopyConfig(options)
+	directory, name := splitRemotePath(remotePath)
+
+	reader, writer := io.Pipe()
+	written := make(chan error, 1)
+
+	go func() {
+		err := writeTar(fs, localPath, name, writer, config)
+		_ = writer.CloseWithError(err)
+		written <- err
+	}()
+
+	command := cmd.New("tar").
+		WithArguments("-xf", "-", "-C", directory).
+		WithStdin(reader)
+
+	_, err := pod.ContainerExecWithContext(pod.client.Ctx, container, command)
+
+	// Unblock the writer in case the command failed before reading all input.
+	_ = reader.Close()
+
+	if err := copyError(err, <-written); err != nil {
+		return fmt.Errorf("failed to copy %s to %s in container %s: %w", localPath, remotePath, container, err)
+	}
+
+	return nil
+}
+
+// CopyFrom copies a file or directory from a pod's container.
+// Directories are copied recursively, file permissions are preserved. The container needs to provide 'tar'.
+//   err := pod.CopyFrom("kafka", "/var/lib/kafka/data", afero.NewOsFs(), "artifacts/kafka-data")
+func (pod Pod) CopyFrom(
+	container string,
+	remotePath string,
+	fs afero.Fs,
+	localPath string,
+	options ...CopyOption) error {
+	config := newCopyConfig(options)
+	directory, name := splitRemotePath(remotePath)
+
+	reader, writer := io.Pipe()
+	executed := make(chan error, 1)
+
+	command := cmd.New("tar").
+		WithArguments("-cf", "-", "-C", directory, name).
+		WithStdout(writer)
+
+	go func() {
+		_, err := pod.ContainerExecWithContext(pod.client.Ctx, container, command)
+		_ = writer.Close()
+		executed <- err
+	}()
+
+	err := readTar(fs, localPath, name, reader, config)
+	if err == nil {
+		// The archive may be padded after its end marker.
+		_, err = io.Copy(ioutil.Discard, reader)
+	}
+
+	// Unblock the command in case reading stopped before all output was read.
+	_ = reader.CloseWithError(err)
+
+	if err := copyError(<-executed, err); err != nil {
+		return fmt.Errorf("failed to copy %s in container %s to %s: %w", remotePath, container, localPath, err)
+	}
+
+	return nil
+}
+
+// splitRemotePath splits a path in a container into the directory to run 'tar' in and the name of the archived entry.
+// The root directory is archived as ".".
+func splitRemotePath(remotePath string) (string, string) {
+	remotePath = path.Clean(remotePath)
+	if remotePath == "/" {
+		return "/", "."
+	}
+
+	return path.Dir(remotePath), path.Base(remotePath)
+}
+
+// copyError combines the error of the 'tar' command in a container with the error of the local side of a copy.
+// Errors caused by the other side stopping early are only reported if there is no other error, errors of the
+// local side passed on to the command are only reported once.
+func copyError(commandErr error, localErr error) error {
+	switch {
+	case localErr == nil:
+		return commandErr
+	case commandErr == nil || errors.Is(commandErr, localErr):
+		return localErr
+	case errors.Is(localErr, io.ErrClosedPipe) || errors.Is(localErr, io.ErrUnexpectedEOF):
+		return commandErr
+	default:
+		return utilerrors.NewAggregate([]error{commandErr, localErr})
+	}
+}
+
+func newCopyConfig(options []CopyOption) CopyConfig {
+	config := CopyConfig{
+		Progress: func(string, int64) {},
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// writeTar writes a file or directory as a tar archive, using 'name' as the name of the root entry.
+func writeTar(fs afero.Fs, root string, name string, writer io.Writer, config CopyConfig) error {
+	archive := tar.NewWriter(writer)
+
+	err := afero.Walk(fs, root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			// Only regular files and directories are supported.
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+
+		header.Name = path.Join(name, filepath.ToSlash(relative))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		size, err := copyFile(fs, file, archive)
+		if err != nil {
+			return err
+		}
+
+		config.Progress(file, size)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func copyFile(fs afero.Fs, file string, writer io.Writer) (int64, error) {
+	input, err := fs.Open(file)
+	if err != nil {
+		return 0, err
+	}
+
+	defer input.Close()
+
+	return io.Copy(writer, input)
+}
+
+// readTar extracts a tar archive, writing the root entry 'name' to 'root'.
+func readTar(fs afero.Fs, root string, name string, reader io.Reader, config CopyConfig) error {
+	archive := tar.NewReader(reader)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		file, err := extractedPath(root, name, header.Name)
+		if err != nil {
+			return err
+		}
+
+		mode := os.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fs.MkdirAll(file, mode); err != nil {
+				return err
+			}
+
+			if err := fs.Chmod(file, mode); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := fs.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return err
+			}
+
+			size, err := extractFile(fs, file, mode, archive)
+			if err != nil {
+				return err
+			}
+
+			config.Progress(file, size)
+		default:
+			// Only regular files and directories are supported.
+			continue
+		}
+	}
+}
+
+func extractFile(fs afero.Fs, file string, mode os.FileMode, reader io.Reader) (int64, error) {
+	output, err := fs.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := io.Copy(output, reader)
+	if err != nil {
+		_ = output.Close()
+		return size, err
+	}
+
+	if err := output.Close(); err != nil {
+		return size, err
+	}
+
+	// The mode of a created file is subject to the umask.
+	return size, fs.Chmod(file, mode)
+}
+
+// extractedPath maps the name of a tar entry to a local path.
+func extractedPath(root string, name string, entry string) (string, error) {
+	entry = path.Clean(entry)
+
+	if entry == name {
+		return root, nil
+	}
+
+	if name == "." && !path.IsAbs(entry) && entry != ".." && !strings.HasPrefix(entry, "../") {
+		return filepath.Join(root, filepath.FromSlash(entry)), nil
+	}
+
+	if !strings.HasPrefix(entry, name+"/") {
+		return "", fmt.Errorf("unexpected entry %s in archive of %s", entry, name)
+	}
+
+	return filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(entry, name+"/"))), nil
+}
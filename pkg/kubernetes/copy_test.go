@@ -0,0 +1,167 @@
+package kubernetes
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+	"github.com/kudobuilder/test-tools/pkg/cmd"
+)
+
+func TestTarRoundTrip(t *testing.T) {
+	source := afero.NewMemMapFs()
+
+	assert.NoError(t, afero.WriteFile(source, "/data/config.properties", []byte("a=b"), 0644))
+	assert.NoError(t, afero.WriteFile(source, "/data/bin/run.sh", []byte("#!/bin/sh"), 0755))
+
+	var archive bytes.Buffer
+
+	var written []string
+
+	config := CopyConfig{
+		Progress: func(file string, size int64) {
+			written = append(written, file)
+		},
+	}
+
+	err := writeTar(source, "/data", "remote", &archive, config)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/data/config.properties", "/data/bin/run.sh"}, written)
+
+	destination := afero.NewMemMapFs()
+
+	err = readTar(destination, "/copy", "remote", &archive, newCopyConfig(nil))
+	assert.NoError(t, err)
+
+	content, err := afero.ReadFile(destination, "/copy/config.properties")
+	assert.NoError(t, err)
+	assert.Equal(t, "a=b", string(content))
+
+	info, err := destination.Stat("/copy/bin/run.sh")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-rwxr-xr-x", info.Mode().String())
+	}
+}
+
+func TestExtractedPath(t *testing.T) {
+	file, err := extractedPath("/copy", "data", "data")
+	assert.NoError(t, err)
+	assert.Equal(t, "/copy", file)
+
+	file, err = extractedPath("/copy", "data", "data/logs/server.log")
+	assert.NoError(t, err)
+	assert.Equal(t, "/copy/logs/server.log", file)
+
+	_, err = extractedPath("/copy", "data", "data/../../etc/passwd")
+	assert.EqualError(t, err, "unexpected entry ../etc/passwd in archive of data")
+}
+
+func TestSplitRemotePath(t *testing.T) {
+	for remotePath, expected := range map[string][2]string{
+		"/var/lib/kafka/data":  {"/var/lib/kafka", "data"},
+		"/var/lib/kafka/data/": {"/var/lib/kafka", "data"},
+		"/data":                {"/", "data"},
+		"/":                    {"/", "."},
+		"data":                 {".", "data"},
+	} {
+		directory, name := splitRemotePath(remotePath)
+		assert.Equal(t, expected, [2]string{directory, name}, remotePath)
+	}
+
+	file, err := extractedPath("/copy", ".", "etc/hosts")
+	assert.NoError(t, err)
+	assert.Equal(t, "/copy/etc/hosts", file)
+
+	_, err = extractedPath("/copy", ".", "../etc/passwd")
+	assert.EqualError(t, err, "unexpected entry ../etc/passwd in archive of .")
+}
+
+func TestCopyTo(t *testing.T) {
+	fake := client.NewFake(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-0", Namespace: "test"}})
+
+	var received bytes.Buffer
+
+	fake.Executor.WithReaction(func(command cmd.Builder) (bool, cmd.Result, error) {
+		_, err := io.Copy(&received, command.Stdin)
+		return command.Command == "tar", cmd.Result{}, err
+	})
+
+	pod, err := GetPod(fake.Client, "kafka-0", "test")
+	assert.NoError(t, err)
+
+	source := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(source, "/data/server.properties", []byte("a=b"), 0644))
+
+	err = pod.CopyTo("kafka", source, "/data", "/opt/kafka/config/")
+	assert.NoError(t, err)
+
+	invocations := fake.Executor.Invocations()
+	if assert.Len(t, invocations, 1) {
+		assert.Equal(t, []string{"-xf", "-", "-C", "/opt/kafka"}, invocations[0].Arguments)
+	}
+
+	destination := afero.NewMemMapFs()
+	assert.NoError(t, readTar(destination, "/copy", "config", &received, CopyConfig{Progress: func(string, int64) {}}))
+
+	data, err := afero.ReadFile(destination, "/copy/server.properties")
+	assert.NoError(t, err)
+	assert.Equal(t, "a=b", string(data))
+
+	err = pod.CopyTo("kafka", source, "/missing", "/opt/kafka/config")
+	assert.Error(t, err)
+	assert.True(t, os.IsNotExist(errors.Unwrap(err)), "the error of the local side should be returned: %v", err)
+}
+
+func TestCopyFrom(t *testing.T) {
+	source := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(source, "/data/logs/server.log", []byte("started"), 0644))
+
+	var archive bytes.Buffer
+
+	assert.NoError(t, writeTar(source, "/data", "data", &archive, CopyConfig{Progress: func(string, int64) {}}))
+
+	// 'tar' pads archives after their end marker.
+	archive.Write(make([]byte, 10240))
+
+	fake := client.NewFake(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-0", Namespace: "test"}})
+
+	fake.Executor.WithReaction(func(command cmd.Builder) (bool, cmd.Result, error) {
+		if command.Arguments[len(command.Arguments)-1] == "missing" {
+			return true, cmd.Result{Stderr: []byte("tar: missing: No such file or directory"), ExitCode: 2}, nil
+		}
+
+		return true, cmd.Result{Stdout: archive.Bytes()}, nil
+	})
+
+	pod, err := GetPod(fake.Client, "kafka-0", "test")
+	assert.NoError(t, err)
+
+	destination := afero.NewMemMapFs()
+
+	err = pod.CopyFrom("kafka", "/var/lib/kafka/data/", destination, "/copy")
+	assert.NoError(t, err)
+
+	data, err := afero.ReadFile(destination, "/copy/logs/server.log")
+	assert.NoError(t, err)
+	assert.Equal(t, "started", string(data))
+
+	err = pod.CopyFrom("kafka", "/var/lib/kafka/missing", destination, "/missing")
+
+	var exitErr ExecExitError
+	if assert.True(t, errors.As(err, &exitErr), "the error of the command should be returned: %v", err) {
+		assert.Equal(t, 2, exitErr.ExitCode)
+	}
+
+	invocations := fake.Executor.Invocations()
+	if assert.Len(t, invocations, 2) {
+		assert.Equal(t, []string{"-cf", "-", "-C", "/var/lib/kafka", "data"}, invocations[0].Arguments)
+	}
+}
@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kudobuilder/test-tools/pkg/cmd"
+)
+
+// ExecExitError is the error returned when a command executed in a container exits with a non-zero exit code.
+type ExecExitError struct {
+	Container string
+	Command   string
+	ExitCode  int
+	Stderr    []byte
+}
+
+// Error returns a pretty-printed error string.
+func (e ExecExitError) Error() string {
+	return fmt.Sprintf(
+		"command \"%s\" in container %s exited with code %d: %s",
+		e.Command,
+		e.Container,
+		e.ExitCode,
+		e.Stderr)
+}
+
+// ExitStatus returns the exit code of the command.
+func (e ExecExitError) ExitStatus() int { return e.ExitCode }
+
+// As allows handling an ExecExitError as the cmd.ExitError returned by other executors.
+func (e ExecExitError) As(target interface{}) bool {
+	exitErr, ok := target.(*cmd.ExitError)
+	if !ok {
+		return false
+	}
+
+	*exitErr = cmd.ExitError{
+		Command:  e.Command,
+		ExitCode: e.ExitCode,
+		Stderr:   e.Stderr,
+	}
+
+	return true
+}
+
+// NamespaceDeletionTimeout is the error returned when waiting for the deletion of a namespace times out.
+// It lists what keeps the namespace from terminating.
+type NamespaceDeletionTimeout struct {
+	Namespace  string
+	Finalizers []string
+	Conditions []string
+	Resources  []string
+}
+
+// Error returns a pretty-printed error string.
+func (n NamespaceDeletionTimeout) Error() string {
+	return fmt.Sprintf(
+		"timed out waiting for deletion of namespace %s; "+
+			"finalizers: [%s], conditions: [%s], resources with finalizers: [%s]",
+		n.Namespace,
+		strings.Join(n.Finalizers, ", "),
+		strings.Join(n.Conditions, "; "),
+		strings.Join(n.Resources, ", "))
+}
+
+// Timeout indicates that this is an error describing a timeout.
+func (NamespaceDeletionTimeout) Timeout() bool { return true }
+
+// Temporary indicates that this is a temporary error.
+func (NamespaceDeletionTimeout) Temporary() bool { return true }
+
+// EventTimeout is the error returned when waiting for an event times out.
+type EventTimeout struct {
+	Namespace string
+	Filter    EventFilter
+}
+
+// Error returns a pretty-printed error string.
+func (e EventTimeout) Error() string {
+	return fmt.Sprintf("timed out waiting for event in namespace %s with %s", e.Namespace, e.Filter)
+}
+
+// Timeout indicates that this is an error describing a timeout.
+func (EventTimeout) Timeout() bool { return true }
+
+// Temporary indicates that this is a temporary error.
+func (EventTimeout) Temporary() bool { return true }
+
+// UnexpectedEvents is the error returned when events occurred that were asserted not to occur.
+type UnexpectedEvents struct {
+	Namespace string
+	Events    []corev1.Event
+}
+
+// Error returns a pretty-printed error string.
+func (u UnexpectedEvents) Error() string {
+	events := make([]string, 0, len(u.Events))
+
+	for _, event := range u.Events {
+		events = append(events, fmt.Sprintf(
+			"%s %s %s/%s: %s",
+			event.Type,
+			event.Reason,
+			strings.ToLower(event.InvolvedObject.Kind),
+			event.InvolvedObject.Name,
+			event.Message))
+	}
+
+	return fmt.Sprintf(
+		"found %d unexpected events in namespace %s: [%s]",
+		len(u.Events),
+		u.Namespace,
+		strings.Join(events, "; "))
+}
@@ -0,0 +1,267 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+// ListEvents lists the Kubernetes Events in a namespace, ordered by the time they last occurred.
+// The events can be filtered with EventOptions, e.g.
+//   events, err := kubernetes.ListEvents(client, namespace,
+//   	kubernetes.EventTypes(corev1.EventTypeWarning),
+//   	kubernetes.EventInvolvedObject("Pod", "zookeeper-0"),
+//   	kubernetes.EventSince(start))
+func ListEvents(client client.Client, namespace string, options ...EventOption) ([]corev1.Event, error) {
+	return listEvents(client, namespace, newEventFilter(options))
+}
+
+// WaitForEvent waits until an event matching a filter occurs in a namespace and returns it.
+// Unless the filter sets a Since time, only events that occurred after the call are considered, so that
+// events of earlier steps of a test don't end the wait. As event timestamps only have a resolution of seconds,
+// this includes events that occurred earlier in the second of the call.
+// Conditions not covered by the fields of the filter, e.g. on the message, can be set as its Predicate.
+// By default it waits for 5 minutes unless overridden with a WaitTimeout.
+//   filter := kubernetes.EventFilter{Reasons: []string{"Killing"}}
+//   event, err := kubernetes.WaitForEvent(client, namespace, filter)
+func WaitForEvent(
+	client client.Client,
+	namespace string,
+	filter EventFilter,
+	options ...WaitOption) (corev1.Event, error) {
+	config := newWaitConfig(options)
+
+	if filter.Since.IsZero() {
+		// Event timestamps only have a resolution of seconds.
+		filter.Since = time.Now().Truncate(time.Second)
+	}
+
+	ctx, cancel := context.WithTimeout(client.Ctx, config.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(config.Retry)
+	defer ticker.Stop()
+
+	for {
+		events, err := listEvents(client, namespace, filter)
+		if err != nil {
+			return corev1.Event{}, err
+		}
+
+		if len(events) > 0 {
+			return events[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return corev1.Event{}, EventTimeout{Namespace: namespace, Filter: filter}
+			}
+
+			return corev1.Event{}, fmt.Errorf("failed to wait for event in namespace %s: %w", namespace, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func listEvents(client client.Client, namespace string, filter EventFilter) ([]corev1.Event, error) {
+	list, err := client.Kubernetes.
+		CoreV1().
+		Events(namespace).
+		List(client.Ctx, metav1.ListOptions{FieldSelector: filter.fieldSelector()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+	}
+
+	events := make([]corev1.Event, 0, len(list.Items))
+
+	// The field selector is only a hint, not every client supports it.
+	for _, event := range list.Items {
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventLastTime(events[i]).Before(EventLastTime(events[j]))
+	})
+
+	return events, nil
+}
+
+// AssertNoWarningEvents checks that no Warning events with one of the reasons occurred in a namespace
+// since a point in time. Without reasons, any Warning event fails the assertion.
+// The returned UnexpectedEvents error lists the offending events.
+//   start := time.Now()
+//   // run the test
+//   err := kubernetes.AssertNoWarningEvents(client, namespace, start, "BackOff", "FailedMount")
+func AssertNoWarningEvents(client client.Client, namespace string, since time.Time, reasons ...string) error {
+	events, err := ListEvents(
+		client,
+		namespace,
+		EventTypes(corev1.EventTypeWarning),
+		EventReasons(reasons...),
+		EventSince(since))
+	if err != nil {
+		return err
+	}
+
+	if len(events) > 0 {
+		return UnexpectedEvents{
+			Namespace: namespace,
+			Events:    events,
+		}
+	}
+
+	return nil
+}
+
+// Matches checks if an event is selected by the filter.
+func (filter EventFilter) Matches(event corev1.Event) bool {
+	if len(filter.Reasons) > 0 && !contains(filter.Reasons, event.Reason) {
+		return false
+	}
+
+	if len(filter.Types) > 0 && !contains(filter.Types, event.Type) {
+		return false
+	}
+
+	if filter.Kind != "" && filter.Kind != event.InvolvedObject.Kind {
+		return false
+	}
+
+	if filter.Name != "" && filter.Name != event.InvolvedObject.Name {
+		return false
+	}
+
+	if filter.UID != "" && filter.UID != event.InvolvedObject.UID {
+		return false
+	}
+
+	if !filter.Since.IsZero() && EventLastTime(event).Before(filter.Since) {
+		return false
+	}
+
+	if !filter.Until.IsZero() && EventFirstTime(event).After(filter.Until) {
+		return false
+	}
+
+	if filter.Predicate != nil && !filter.Predicate(event) {
+		return false
+	}
+
+	return true
+}
+
+// String describes the filter, e.g. "reasons [Killing], kind Pod, name kafka-0".
+func (filter EventFilter) String() string {
+	var criteria []string
+
+	if len(filter.Reasons) > 0 {
+		criteria = append(criteria, fmt.Sprintf("reasons %v", filter.Reasons))
+	}
+
+	if len(filter.Types) > 0 {
+		criteria = append(criteria, fmt.Sprintf("types %v", filter.Types))
+	}
+
+	if filter.Kind != "" {
+		criteria = append(criteria, fmt.Sprintf("kind %s", filter.Kind))
+	}
+
+	if filter.Name != "" {
+		criteria = append(criteria, fmt.Sprintf("name %s", filter.Name))
+	}
+
+	if filter.UID != "" {
+		criteria = append(criteria, fmt.Sprintf("uid %s", filter.UID))
+	}
+
+	if !filter.Since.IsZero() {
+		criteria = append(criteria, fmt.Sprintf("since %s", filter.Since.UTC().Format(time.RFC3339)))
+	}
+
+	if !filter.Until.IsZero() {
+		criteria = append(criteria, fmt.Sprintf("until %s", filter.Until.UTC().Format(time.RFC3339)))
+	}
+
+	if filter.Predicate != nil {
+		criteria = append(criteria, "a predicate")
+	}
+
+	if len(criteria) == 0 {
+		return "any event"
+	}
+
+	return strings.Join(criteria, ", ")
+}
+
+func (filter EventFilter) fieldSelector() string {
+	set := fields.Set{}
+
+	if filter.Kind != "" {
+		set["involvedObject.kind"] = filter.Kind
+	}
+
+	if filter.Name != "" {
+		set["involvedObject.name"] = filter.Name
+	}
+
+	if filter.UID != "" {
+		set["involvedObject.uid"] = string(filter.UID)
+	}
+
+	if len(filter.Reasons) == 1 {
+		set["reason"] = filter.Reasons[0]
+	}
+
+	if len(filter.Types) == 1 {
+		set["type"] = filter.Types[0]
+	}
+
+	return set.String()
+}
+
+// EventFirstTime returns when an event first occurred.
+// Depending on the reporting component, events set different timestamps, this checks all of them.
+func EventFirstTime(event corev1.Event) time.Time {
+	switch {
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// EventLastTime returns when an event last occurred.
+func EventLastTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	default:
+		return EventFirstTime(event)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
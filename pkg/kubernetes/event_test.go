@@ -0,0 +1,186 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func testEvent(name string, eventType string, reason string, pod string, first, last time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "Pod",
+			Name: pod,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason + " " + pod,
+		FirstTimestamp: metav1.NewTime(first),
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func eventNames(events []corev1.Event) []string {
+	names := make([]string, 0, len(events))
+
+	for _, event := range events {
+		names = append(names, event.Name)
+	}
+
+	return names
+}
+
+func TestListEvents(t *testing.T) {
+	start := time.Now()
+
+	client := client.Client{
+		Ctx: context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(
+			testEvent("killing", corev1.EventTypeNormal, "Killing", "pod-0", start, start.Add(time.Minute)),
+			testEvent("scheduled", corev1.EventTypeNormal, "Scheduled", "pod-0", start, start),
+			testEvent("backoff", corev1.EventTypeWarning, "BackOff", "pod-1", start.Add(-time.Hour), start.Add(time.Second)),
+			testEvent("old", corev1.EventTypeWarning, "FailedMount", "pod-1", start.Add(-time.Hour), start.Add(-time.Hour)),
+		),
+	}
+
+	tests := []struct {
+		name     string
+		options  []EventOption
+		expected []string
+	}{
+		{
+			name:     "all events ordered by last occurrence",
+			expected: []string{"old", "scheduled", "backoff", "killing"},
+		},
+		{
+			name:     "involved object",
+			options:  []EventOption{EventInvolvedObject("Pod", "pod-0")},
+			expected: []string{"scheduled", "killing"},
+		},
+		{
+			name:     "type",
+			options:  []EventOption{EventTypes(corev1.EventTypeWarning)},
+			expected: []string{"old", "backoff"},
+		},
+		{
+			name:     "reasons",
+			options:  []EventOption{EventReasons("Killing", "BackOff")},
+			expected: []string{"backoff", "killing"},
+		},
+		{
+			name:     "time window",
+			options:  []EventOption{EventSince(start), EventUntil(start.Add(-time.Minute))},
+			expected: []string{"backoff"},
+		},
+		{
+			name: "predicate",
+			options: []EventOption{EventMatching(func(event corev1.Event) bool {
+				return strings.HasSuffix(event.Message, "pod-1")
+			})},
+			expected: []string{"old", "backoff"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			events, err := ListEvents(client, "test", test.options...)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, eventNames(events))
+		})
+	}
+}
+
+func TestWaitForEvent(t *testing.T) {
+	now := time.Now()
+
+	client := client.Client{
+		Ctx: context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(
+			testEvent("earlier", corev1.EventTypeWarning, "Evicted", "pod-0", now.Add(-time.Hour), now.Add(-time.Hour)),
+		),
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		occurred := time.Now()
+
+		_, _ = client.Kubernetes.CoreV1().Events("test").Create(
+			context.TODO(),
+			testEvent("evicted", corev1.EventTypeWarning, "Evicted", "pod-0", occurred, occurred),
+			metav1.CreateOptions{})
+	}()
+
+	filter := EventFilter{Reasons: []string{"Evicted"}}
+
+	event, err := WaitForEvent(client, "test", filter, WaitRetry(10*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, "evicted", event.Name, "events that occurred before the call should be ignored")
+
+	filter = EventFilter{Reasons: []string{"Evicted"}, Since: now.Add(-2 * time.Hour)}
+
+	event, err = WaitForEvent(client, "test", filter, WaitRetry(10*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, "earlier", event.Name)
+
+	filter = EventFilter{
+		Since:     now.Add(-2 * time.Hour),
+		Predicate: func(event corev1.Event) bool { return event.Name != "earlier" },
+	}
+
+	event, err = WaitForEvent(client, "test", filter, WaitRetry(10*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, "evicted", event.Name)
+
+	filter = EventFilter{Reasons: []string{"Scheduled"}, Kind: "Pod", Name: "pod-0"}
+
+	_, err = WaitForEvent(client, "test", filter, WaitTimeout(50*time.Millisecond), WaitRetry(10*time.Millisecond))
+
+	var timeout EventTimeout
+
+	if assert.True(t, errors.As(err, &timeout)) {
+		assert.Equal(t, "test", timeout.Namespace)
+		assert.Equal(t, []string{"Scheduled"}, timeout.Filter.Reasons)
+		assert.False(t, timeout.Filter.Since.IsZero())
+		assert.Contains(t, err.Error(),
+			"timed out waiting for event in namespace test with reasons [Scheduled], kind Pod, name pod-0, since ")
+	}
+}
+
+func TestAssertNoWarningEvents(t *testing.T) {
+	start := time.Now()
+
+	client := client.Client{
+		Ctx: context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(
+			testEvent("backoff", corev1.EventTypeWarning, "BackOff", "pod-0", start, start.Add(time.Second)),
+			testEvent("old", corev1.EventTypeWarning, "FailedMount", "pod-0", start.Add(-time.Hour), start.Add(-time.Hour)),
+			testEvent("killing", corev1.EventTypeNormal, "Killing", "pod-0", start, start),
+		),
+	}
+
+	assert.NoError(t, AssertNoWarningEvents(client, "test", start, "FailedMount"))
+
+	err := AssertNoWarningEvents(client, "test", start)
+	assert.EqualError(t, err, "found 1 unexpected events in namespace test: [Warning BackOff pod/pod-0: BackOff pod-0]")
+
+	var unexpected UnexpectedEvents
+
+	assert.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, []string{"backoff"}, eventNames(unexpected.Events))
+}
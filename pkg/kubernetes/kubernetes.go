@@ -1,2 +1,4 @@
 // Package kubernetes implements a convenience wrapper around Kubernetes clients.
 package kubernetes
+
+//go:generate stub-gen -config stub-gen.yaml
@@ -0,0 +1,215 @@
+package kubernetes
+
+// Code generated by stub-gen; DO NOT EDIT.
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+// Namespace wraps a Kubernetes Namespace.
+type Namespace struct {
+	corev1.Namespace
+
+	client client.Client
+}
+
+// NewNamespace creates a Namespace from its Kubernetes Namespace.
+func NewNamespace(client client.Client, namespace corev1.Namespace) (Namespace, error) {
+	createdNamespace, err := client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		Create(client.Ctx, &namespace, metav1.CreateOptions{})
+	if err != nil {
+		return Namespace{}, fmt.Errorf("failed to create namespace %s: %w", namespace.Name, err)
+	}
+
+	result := Namespace{
+		Namespace: *createdNamespace,
+		client:    client,
+	}
+
+	client.Track(fmt.Sprintf("namespace %s", result.Name), result.Delete)
+
+	return result, nil
+}
+
+// GetNamespace gets a namespace.
+func GetNamespace(client client.Client, name string) (Namespace, error) {
+	options := metav1.GetOptions{}
+
+	namespace, err := client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		Get(client.Ctx, name, options)
+	if err != nil {
+		return Namespace{}, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+
+	return Namespace{
+		Namespace: *namespace,
+		client:    client,
+	}, nil
+}
+
+// ListNamespaces lists all namespaces.
+func ListNamespaces(client client.Client) ([]Namespace, error) {
+	options := metav1.ListOptions{}
+
+	list, err := client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		List(client.Ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := make([]Namespace, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		namespaces = append(namespaces, Namespace{
+			Namespace: item,
+			client:    client,
+		})
+	}
+
+	return namespaces, nil
+}
+
+// NamespaceEvent is an event received while watching namespaces.
+type NamespaceEvent struct {
+	Type      watch.EventType
+	Namespace Namespace
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchNamespaces watches namespaces.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchNamespaces(client client.Client, options metav1.ListOptions) (<-chan NamespaceEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch namespaces: %w", err)
+	}
+
+	events := make(chan NamespaceEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := NamespaceEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.Namespace)
+			if ok {
+				result.Namespace = Namespace{
+					Namespace: *object,
+					client:    client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch namespaces: %w", apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the Namespace, ordered by their first occurrence.
+// Events of an earlier namespace with the same name are not included.
+func (namespace Namespace) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		namespace.client,
+		metav1.NamespaceAll,
+		EventInvolvedObject("Namespace", namespace.Name),
+		EventInvolvedUID(namespace.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
+// Delete deletes a Namespace from the Kubernetes cluster.
+func (namespace Namespace) Delete() error {
+	options := metav1.DeleteOptions{}
+
+	err := namespace.client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		Delete(namespace.client.Ctx, namespace.Name, options)
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", namespace.Name, err)
+	}
+
+	return nil
+}
+
+// Update gets the current Namespace status.
+func (namespace *Namespace) Update() error {
+	options := metav1.GetOptions{}
+
+	update, err := namespace.client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		Get(namespace.client.Ctx, namespace.Name, options)
+	if err != nil {
+		return fmt.Errorf("failed to update namespace %s: %w", namespace.Name, err)
+	}
+
+	namespace.Namespace = *update
+
+	return nil
+}
+
+// Save saves the current Namespace.
+func (namespace *Namespace) Save() error {
+	update, err := namespace.client.Kubernetes.
+		CoreV1().
+		Namespaces().
+		Update(namespace.client.Ctx, &namespace.Namespace, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to save namespace %s: %w", namespace.Name, err)
+	}
+
+	namespace.Namespace = *update
+
+	return nil
+}
@@ -1,46 +1,264 @@
 package kubernetes
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/discovery"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
 
-// CreateNamespace creates a namespace.
-func CreateNamespace(client client.Client, name string) error {
+const (
+	testNamespaceSuffixLength = 5
+	// testNamespaceDeletionTimeout is how long the cleanup of a test namespace waits for its termination.
+	testNamespaceDeletionTimeout = time.Minute * 5
+	// deletionReportTimeout bounds listing what blocks the termination of a namespace once waiting timed out.
+	deletionReportTimeout = time.Second * 30
+)
+
+// NamespaceBuilder tracks the options set for a namespace.
+type NamespaceBuilder struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// BuildNamespace creates a namespace.
+// Additional parameters can be added to this call.
+// The creation is started by calling 'Do'.
+//   namespace, err := kubernetes.BuildNamespace("kafka").
+//   	WithLabels(map[string]string{"istio-injection": "enabled"}).
+//   	Do(client)
+func BuildNamespace(name string) NamespaceBuilder {
+	return NamespaceBuilder{
+		Name: name,
+	}
+}
+
+// WithLabels sets the labels of the namespace.
+func (builder NamespaceBuilder) WithLabels(labels map[string]string) NamespaceBuilder {
+	builder.Labels = labels
+
+	return builder
+}
+
+// WithAnnotations sets the annotations of the namespace.
+func (builder NamespaceBuilder) WithAnnotations(annotations map[string]string) NamespaceBuilder {
+	builder.Annotations = annotations
+
+	return builder
+}
+
+// Do creates the namespace in the cluster.
+func (builder NamespaceBuilder) Do(client client.Client) (Namespace, error) {
 	namespace := corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name:        builder.Name,
+			Labels:      builder.Labels,
+			Annotations: builder.Annotations,
 		},
 	}
 
-	_, err := client.Kubernetes.
-		CoreV1().
-		Namespaces().
-		Create(client.Ctx, &namespace, metav1.CreateOptions{})
+	return NewNamespace(client, namespace)
+}
 
-	if err != nil {
-		return fmt.Errorf("failed to create namespace %s: %w", name, err)
-	}
+// CreateNamespace creates a namespace.
+func CreateNamespace(client client.Client, name string) error {
+	_, err := BuildNamespace(name).Do(client)
 
-	return nil
+	return err
 }
 
 // DeleteNamespace deletes a namespace.
-func DeleteNamespace(client client.Client, name string) error {
-	options := metav1.DeleteOptions{}
-
+// By default it doesn't wait for the namespace to terminate. If WaitOptions are set, it waits until the namespace
+// has terminated, and a NamespaceDeletionTimeout lists what blocks the termination if the wait times out.
+//   err := kubernetes.DeleteNamespace(client, "kafka", kubernetes.WaitTimeout(time.Minute))
+func DeleteNamespace(client client.Client, name string, options ...WaitOption) error {
 	err := client.Kubernetes.
 		CoreV1().
 		Namespaces().
-		Delete(client.Ctx, name, options)
+		Delete(client.Ctx, name, metav1.DeleteOptions{})
 
 	if err != nil {
 		return fmt.Errorf("failed to delete namespace %s: %w", name, err)
 	}
 
-	return nil
+	if len(options) == 0 {
+		return nil
+	}
+
+	return waitForNamespaceDeletion(client, name, newWaitConfig(options))
+}
+
+// WaitForDeletion waits until the namespace has terminated.
+// If the wait times out, a NamespaceDeletionTimeout lists what blocks the termination.
+// By default it waits for 5 minutes unless overridden with a WaitTimeout.
+//   err := namespace.Delete()
+//   if err != nil ...
+//   err = namespace.WaitForDeletion(kubernetes.WaitTimeout(time.Minute))
+func (namespace Namespace) WaitForDeletion(options ...WaitOption) error {
+	return waitForNamespaceDeletion(namespace.client, namespace.Name, newWaitConfig(options))
+}
+
+// TestingT is the subset of testing.TB used to clean up after a test.
+type TestingT interface {
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
+
+// NewTestNamespace creates a namespace with a unique name for a test.
+// The name consists of the prefix and a random suffix, so that tests running in parallel don't collide.
+// The namespace is deleted once the test and all its subtests completed. The cleanup waits for the namespace
+// to terminate, so that later tests don't run into its remaining resources.
+//   namespace, err := kubernetes.NewTestNamespace(t, client, "kafka")
+func NewTestNamespace(t TestingT, client client.Client, prefix string) (Namespace, error) {
+	name := fmt.Sprintf("%s-%s", prefix, utilrand.String(testNamespaceSuffixLength))
+
+	namespace, err := BuildNamespace(name).Do(client)
+	if err != nil {
+		return Namespace{}, err
+	}
+
+	t.Cleanup(func() {
+		err := DeleteNamespace(client, name, WaitTimeout(testNamespaceDeletionTimeout))
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Errorf("%v", err)
+		}
+	})
+
+	return namespace, nil
+}
+
+func waitForNamespaceDeletion(client client.Client, name string, config WaitConfig) error {
+	ctx, cancel := context.WithTimeout(client.Ctx, config.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(config.Retry)
+	defer ticker.Stop()
+
+	// The last state of the namespace is reported if the wait times out.
+	namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+	for {
+		current, err := client.Kubernetes.
+			CoreV1().
+			Namespaces().
+			Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to wait for deletion of namespace %s: %w", name, err)
+		}
+
+		if err == nil {
+			namespace = *current
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return namespaceDeletionTimeout(client, namespace)
+			}
+
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func namespaceDeletionTimeout(client client.Client, namespace corev1.Namespace) NamespaceDeletionTimeout {
+	ctx, cancel := context.WithTimeout(client.Ctx, deletionReportTimeout)
+	defer cancel()
+
+	timeout := NamespaceDeletionTimeout{
+		Namespace: namespace.Name,
+		Resources: finalizedResources(ctx, client, namespace.Name),
+	}
+
+	for _, finalizer := range namespace.Spec.Finalizers {
+		timeout.Finalizers = append(timeout.Finalizers, string(finalizer))
+	}
+
+	for _, condition := range namespace.Status.Conditions {
+		if condition.Status == corev1.ConditionTrue {
+			timeout.Conditions = append(timeout.Conditions, condition.Message)
+		}
+	}
+
+	return timeout
+}
+
+// finalizedResources lists the resources in a namespace that have finalizers.
+// All resources that can be listed are checked, including custom resources. Resources that can't be discovered
+// or listed are skipped, as this is only used to explain why a namespace deletion didn't complete.
+func finalizedResources(ctx context.Context, client client.Client, namespace string) []string {
+	if client.Dynamic == nil {
+		return nil
+	}
+
+	// Continue with the groups that could be discovered.
+	groups, lists, err := client.Kubernetes.Discovery().ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil
+	}
+
+	preferredVersions := make(map[string]string, len(groups))
+
+	for _, group := range groups {
+		preferredVersions[group.Name] = group.PreferredVersion.GroupVersion
+	}
+
+	lists = discovery.FilteredBy(discovery.ResourcePredicateFunc(
+		func(groupVersion string, resource *metav1.APIResource) bool {
+			gv, err := schema.ParseGroupVersion(groupVersion)
+
+			// Subresources can't be listed on their own.
+			return err == nil &&
+				preferredVersions[gv.Group] == groupVersion &&
+				resource.Namespaced &&
+				!strings.Contains(resource.Name, "/")
+		}), lists)
+	lists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, lists)
+
+	gvrs, err := discovery.GroupVersionResources(lists)
+	if err != nil {
+		return nil
+	}
+
+	var resources []string
+
+	for gvr := range gvrs {
+		list, err := client.Dynamic.
+			Resource(gvr).
+			Namespace(namespace).
+			List(ctx, metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+
+		for _, item := range list.Items {
+			if len(item.GetFinalizers()) == 0 {
+				continue
+			}
+
+			resources = append(resources, fmt.Sprintf(
+				"%s/%s (%s)", gvr.GroupResource(), item.GetName(), strings.Join(item.GetFinalizers(), ", ")))
+		}
+	}
+
+	sort.Strings(resources)
+
+	return resources
 }
@@ -2,11 +2,18 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -46,3 +53,175 @@ func TestNamespace(t *testing.T) {
 		Get(client.Ctx, namespace, metav1.GetOptions{})
 	assert.Error(t, err)
 }
+
+func TestBuildNamespace(t *testing.T) {
+	client := client.Client{
+		Ctx:        context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(),
+	}
+
+	labels := map[string]string{"test": "true"}
+	annotations := map[string]string{"owner": "test-tools"}
+
+	namespace, err := BuildNamespace("test").
+		WithLabels(labels).
+		WithAnnotations(annotations).
+		Do(client)
+	assert.NoError(t, err)
+
+	namespace, err = GetNamespace(client, namespace.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, labels, namespace.Labels)
+	assert.Equal(t, annotations, namespace.Annotations)
+
+	err = namespace.Delete()
+	assert.NoError(t, err)
+
+	err = namespace.WaitForDeletion(WaitTimeout(time.Second))
+	assert.NoError(t, err)
+}
+
+func TestDeleteNamespaceTimeout(t *testing.T) {
+	const namespace = "test"
+
+	testNamespace := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+		Spec: corev1.NamespaceSpec{
+			Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes},
+		},
+		Status: corev1.NamespaceStatus{
+			Conditions: []corev1.NamespaceCondition{
+				{
+					Type:    corev1.NamespaceFinalizersRemaining,
+					Status:  corev1.ConditionTrue,
+					Message: "Some content in the namespace has finalizers remaining: kubernetes.io/pvc-protection",
+				},
+			},
+		},
+	}
+
+	testPVC := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata": map[string]interface{}{
+			"name":       "data",
+			"namespace":  namespace,
+			"finalizers": []interface{}{"kubernetes.io/pvc-protection"},
+		},
+	}}
+
+	testInstance := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kudo.dev/v1beta1",
+		"kind":       "Instance",
+		"metadata": map[string]interface{}{
+			"name":       "kafka",
+			"namespace":  namespace,
+			"finalizers": []interface{}{"kudo.dev/cleanup"},
+		},
+	}}
+
+	fake := client.NewFake(testNamespace.DeepCopyObject(), testPVC.DeepCopyObject(), testInstance.DeepCopyObject())
+	fake.FakeKubernetes.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "persistentvolumeclaims", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+				{Name: "pods", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+				{Name: "pods/log", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+			},
+		},
+		{
+			GroupVersion: "kudo.dev/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "instances", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			},
+		},
+	}
+
+	// Deleting the namespace never completes.
+	fake.FakeKubernetes.PrependReactor("delete", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	namespaceToDelete, err := GetNamespace(fake.Client, namespace)
+	assert.NoError(t, err)
+
+	err = namespaceToDelete.Delete()
+	assert.NoError(t, err)
+
+	expected := NamespaceDeletionTimeout{
+		Namespace:  namespace,
+		Finalizers: []string{"kubernetes"},
+		Conditions: []string{"Some content in the namespace has finalizers remaining: kubernetes.io/pvc-protection"},
+		Resources: []string{
+			"instances.kudo.dev/kafka (kudo.dev/cleanup)",
+			"persistentvolumeclaims/data (kubernetes.io/pvc-protection)",
+		},
+	}
+
+	err = namespaceToDelete.WaitForDeletion(WaitTimeout(time.Millisecond*10), WaitRetry(time.Millisecond))
+	assert.Equal(t, expected, err)
+
+	err = DeleteNamespace(fake.Client, namespace, WaitTimeout(time.Millisecond*10), WaitRetry(time.Millisecond))
+	assert.Equal(t, expected, err)
+}
+
+type fakeT struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (t *fakeT) Cleanup(cleanup func()) {
+	t.cleanups = append(t.cleanups, cleanup)
+}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewTestNamespace(t *testing.T) {
+	client := client.Client{
+		Ctx:        context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(),
+	}
+
+	test := &fakeT{}
+
+	first, err := NewTestNamespace(test, client, "kafka")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first.Name, "kafka-"))
+
+	second, err := NewTestNamespace(test, client, "kafka")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Name, second.Name)
+
+	for _, cleanup := range test.cleanups {
+		cleanup()
+	}
+
+	assert.Empty(t, test.errors)
+
+	namespaces, err := ListNamespaces(client)
+	assert.NoError(t, err)
+	assert.Empty(t, namespaces)
+}
+
+func TestTrackedNamespace(t *testing.T) {
+	tracker := client.NewTracker()
+	client := client.Client{
+		Ctx:        context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(),
+	}.WithTracker(tracker)
+
+	_, err := BuildNamespace("test").Do(client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"namespace test"}, tracker.Tracked())
+
+	assert.NoError(t, tracker.Cleanup())
+
+	namespaces, err := ListNamespaces(client)
+	assert.NoError(t, err)
+	assert.Empty(t, namespaces)
+}
@@ -4,9 +4,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +32,14 @@ func NewNode(client client.Client, node corev1.Node) (Node, error) {
 		return Node{}, fmt.Errorf("failed to create node %s: %w", node.Name, err)
 	}
 
-	return Node{
-		Node: *createdNode,
+	result := Node{
+		Node:   *createdNode,
 		client: client,
-	}, nil
+	}
+
+	client.Track(fmt.Sprintf("node %s", result.Name), result.Delete)
+
+	return result, nil
 }
 
 // GetNode gets a node.
@@ -47,7 +55,7 @@ func GetNode(client client.Client, name string) (Node, error) {
 	}
 
 	return Node{
-		Node: *node,
+		Node:   *node,
 		client: client,
 	}, nil
 }
@@ -68,7 +76,7 @@ func ListNodes(client client.Client) ([]Node, error) {
 
 	for _, item := range list.Items {
 		nodes = append(nodes, Node{
-			Node: item,
+			Node:   item,
 			client: client,
 		})
 	}
@@ -76,6 +84,89 @@ func ListNodes(client client.Client) ([]Node, error) {
 	return nodes, nil
 }
 
+// NodeEvent is an event received while watching nodes.
+type NodeEvent struct {
+	Type watch.EventType
+	Node Node
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchNodes watches nodes.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchNodes(client client.Client, options metav1.ListOptions) (<-chan NodeEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		Nodes().
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch nodes: %w", err)
+	}
+
+	events := make(chan NodeEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := NodeEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.Node)
+			if ok {
+				result.Node = Node{
+					Node:   *object,
+					client: client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch nodes: %w", apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the Node, ordered by their first occurrence.
+// Events of an earlier node with the same name are not included.
+func (node Node) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		node.client,
+		metav1.NamespaceAll,
+		EventInvolvedObject("Node", node.Name),
+		EventInvolvedUID(node.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a Node from the Kubernetes cluster.
 func (node Node) Delete() error {
 	options := metav1.DeleteOptions{}
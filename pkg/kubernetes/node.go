@@ -1,3 +0,0 @@
-package kubernetes
-
-//go:generate stub-gen -api CoreV1 -type Node -hasNamespace=false
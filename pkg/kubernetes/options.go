@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecOption changes an ExecConfig.
+type ExecOption func(*ExecConfig)
+
+// ExecTTY allocates a TTY for a container exec call.
+// With a TTY, the error output of the command is merged into its output.
+func ExecTTY() ExecOption {
+	return func(config *ExecConfig) {
+		config.TTY = true
+	}
+}
+
+// ExecTerminalSizeQueue sets the queue providing terminal resize events to a container exec call.
+// This implies ExecTTY.
+func ExecTerminalSizeQueue(queue remotecommand.TerminalSizeQueue) ExecOption {
+	return func(config *ExecConfig) {
+		config.TTY = true
+		config.TerminalSizeQueue = queue
+	}
+}
+
+// LogConfig is used to configure container log calls.
+type LogConfig struct {
+	Previous  bool
+	TailLines *int64
+}
+
+// LogOption changes a LogConfig.
+type LogOption func(*LogConfig)
+
+// LogPrevious returns the logs of the previous instance of a container, e.g. after it crashed.
+func LogPrevious() LogOption {
+	return func(config *LogConfig) {
+		config.Previous = true
+	}
+}
+
+// LogTailLines limits the logs to a number of lines from their end.
+func LogTailLines(lines int64) LogOption {
+	return func(config *LogConfig) {
+		config.TailLines = &lines
+	}
+}
+
+func newLogConfig(options []LogOption) LogConfig {
+	config := LogConfig{}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// CopyOption changes a CopyConfig.
+type CopyOption func(*CopyConfig)
+
+// CopyProgress sets a function that is called for every file that has been copied.
+func CopyProgress(progress func(file string, size int64)) CopyOption {
+	return func(config *CopyConfig) {
+		config.Progress = progress
+	}
+}
+
+// WaitConfig is used to configure wait calls.
+type WaitConfig struct {
+	Timeout time.Duration
+	Retry   time.Duration
+}
+
+// WaitOption changes a WaitConfig.
+type WaitOption func(*WaitConfig)
+
+// WaitTimeout sets the timeout of a wait call.
+func WaitTimeout(timeout time.Duration) WaitOption {
+	return func(config *WaitConfig) {
+		config.Timeout = timeout
+	}
+}
+
+// WaitRetry sets the interval in which a wait call checks its condition.
+func WaitRetry(retry time.Duration) WaitOption {
+	return func(config *WaitConfig) {
+		config.Retry = retry
+	}
+}
+
+func newWaitConfig(options []WaitOption) WaitConfig {
+	config := WaitConfig{
+		Timeout: time.Minute * 5,
+		Retry:   time.Second * 2,
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// EventFilter selects Kubernetes Events. Empty fields match every event.
+type EventFilter struct {
+	Reasons []string
+	Types   []string
+	Kind    string
+	Name    string
+	UID     types.UID
+	Since   time.Time
+	Until   time.Time
+	// Predicate selects events by any other condition, e.g. their message.
+	Predicate func(corev1.Event) bool
+}
+
+// EventOption changes an EventFilter.
+type EventOption func(*EventFilter)
+
+// EventReasons selects events with one of the reasons.
+func EventReasons(reasons ...string) EventOption {
+	return func(filter *EventFilter) {
+		filter.Reasons = append(filter.Reasons, reasons...)
+	}
+}
+
+// EventTypes selects events of one of the types, e.g. corev1.EventTypeWarning.
+func EventTypes(types ...string) EventOption {
+	return func(filter *EventFilter) {
+		filter.Types = append(filter.Types, types...)
+	}
+}
+
+// EventInvolvedObject selects events involving an object.
+func EventInvolvedObject(kind string, name string) EventOption {
+	return func(filter *EventFilter) {
+		filter.Kind = kind
+		filter.Name = name
+	}
+}
+
+// EventInvolvedUID selects events involving the object with a UID.
+// Unlike EventInvolvedObject, this doesn't match events of an earlier object with the same name.
+func EventInvolvedUID(uid types.UID) EventOption {
+	return func(filter *EventFilter) {
+		filter.UID = uid
+	}
+}
+
+// EventMatching selects events for which the predicate returns true, e.g.
+//   kubernetes.EventMatching(func(event corev1.Event) bool {
+//   	return strings.Contains(event.Message, "Readiness probe failed")
+//   })
+func EventMatching(predicate func(corev1.Event) bool) EventOption {
+	return func(filter *EventFilter) {
+		filter.Predicate = predicate
+	}
+}
+
+// EventSince selects events that last occurred at or after a time.
+func EventSince(since time.Time) EventOption {
+	return func(filter *EventFilter) {
+		filter.Since = since
+	}
+}
+
+// EventUntil selects events that first occurred at or before a time.
+func EventUntil(until time.Time) EventOption {
+	return func(filter *EventFilter) {
+		filter.Until = until
+	}
+}
+
+func newEventFilter(options []EventOption) EventFilter {
+	filter := EventFilter{}
+
+	for _, option := range options {
+		option(&filter)
+	}
+
+	return filter
+}
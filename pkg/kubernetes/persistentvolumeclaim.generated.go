@@ -4,9 +4,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +32,14 @@ func NewPersistentVolumeClaim(client client.Client, persistentvolumeclaim corev1
 		return PersistentVolumeClaim{}, fmt.Errorf("failed to create persistentvolumeclaim %s in namespace %s: %w", persistentvolumeclaim.Name, persistentvolumeclaim.Namespace, err)
 	}
 
-	return PersistentVolumeClaim{
+	result := PersistentVolumeClaim{
 		PersistentVolumeClaim: *createdPersistentVolumeClaim,
-		client: client,
-	}, nil
+		client:                client,
+	}
+
+	client.Track(fmt.Sprintf("persistentvolumeclaim %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetPersistentVolumeClaim gets a persistentvolumeclaim in a namespace.
@@ -48,7 +56,7 @@ func GetPersistentVolumeClaim(client client.Client, name string, namespace strin
 
 	return PersistentVolumeClaim{
 		PersistentVolumeClaim: *persistentvolumeclaim,
-		client: client,
+		client:                client,
 	}, nil
 }
 
@@ -69,13 +77,96 @@ func ListPersistentVolumeClaims(client client.Client, namespace string) ([]Persi
 	for _, item := range list.Items {
 		persistentvolumeclaims = append(persistentvolumeclaims, PersistentVolumeClaim{
 			PersistentVolumeClaim: item,
-			client: client,
+			client:                client,
 		})
 	}
 
 	return persistentvolumeclaims, nil
 }
 
+// PersistentVolumeClaimEvent is an event received while watching persistentvolumeclaims.
+type PersistentVolumeClaimEvent struct {
+	Type                  watch.EventType
+	PersistentVolumeClaim PersistentVolumeClaim
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchPersistentVolumeClaims watches persistentvolumeclaims in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchPersistentVolumeClaims(client client.Client, namespace string, options metav1.ListOptions) (<-chan PersistentVolumeClaimEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		PersistentVolumeClaims(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch persistentvolumeclaims in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan PersistentVolumeClaimEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := PersistentVolumeClaimEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.PersistentVolumeClaim)
+			if ok {
+				result.PersistentVolumeClaim = PersistentVolumeClaim{
+					PersistentVolumeClaim: *object,
+					client:                client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch persistentvolumeclaims in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the PersistentVolumeClaim, ordered by their first occurrence.
+// Events of an earlier persistentvolumeclaim with the same name are not included.
+func (persistentvolumeclaim PersistentVolumeClaim) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		persistentvolumeclaim.client,
+		persistentvolumeclaim.Namespace,
+		EventInvolvedObject("PersistentVolumeClaim", persistentvolumeclaim.Name),
+		EventInvolvedUID(persistentvolumeclaim.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a PersistentVolumeClaim from the Kubernetes cluster.
 func (persistentvolumeclaim PersistentVolumeClaim) Delete() error {
 	options := metav1.DeleteOptions{}
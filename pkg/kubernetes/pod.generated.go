@@ -4,9 +4,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +32,14 @@ func NewPod(client client.Client, pod corev1.Pod) (Pod, error) {
 		return Pod{}, fmt.Errorf("failed to create pod %s in namespace %s: %w", pod.Name, pod.Namespace, err)
 	}
 
-	return Pod{
-		Pod: *createdPod,
+	result := Pod{
+		Pod:    *createdPod,
 		client: client,
-	}, nil
+	}
+
+	client.Track(fmt.Sprintf("pod %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetPod gets a pod in a namespace.
@@ -47,7 +55,7 @@ func GetPod(client client.Client, name string, namespace string) (Pod, error) {
 	}
 
 	return Pod{
-		Pod: *pod,
+		Pod:    *pod,
 		client: client,
 	}, nil
 }
@@ -68,7 +76,7 @@ func ListPods(client client.Client, namespace string) ([]Pod, error) {
 
 	for _, item := range list.Items {
 		pods = append(pods, Pod{
-			Pod: item,
+			Pod:    item,
 			client: client,
 		})
 	}
@@ -76,6 +84,89 @@ func ListPods(client client.Client, namespace string) ([]Pod, error) {
 	return pods, nil
 }
 
+// PodEvent is an event received while watching pods.
+type PodEvent struct {
+	Type watch.EventType
+	Pod  Pod
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchPods watches pods in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchPods(client client.Client, namespace string, options metav1.ListOptions) (<-chan PodEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		Pods(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch pods in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan PodEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := PodEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.Pod)
+			if ok {
+				result.Pod = Pod{
+					Pod:    *object,
+					client: client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch pods in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the Pod, ordered by their first occurrence.
+// Events of an earlier pod with the same name are not included.
+func (pod Pod) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		pod.client,
+		pod.Namespace,
+		EventInvolvedObject("Pod", pod.Name),
+		EventInvolvedUID(pod.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a Pod from the Kubernetes cluster.
 func (pod Pod) Delete() error {
 	options := metav1.DeleteOptions{}
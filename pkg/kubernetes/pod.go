@@ -1,21 +1,53 @@
 package kubernetes
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 
 	"github.com/kudobuilder/test-tools/pkg/cmd"
 )
 
-//go:generate stub-gen -api CoreV1 -type Pod
+// ExecConfig is used to configure container exec calls.
+type ExecConfig struct {
+	TTY               bool
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// ExecResult holds the captured output and the exit code of a command executed in a container.
+type ExecResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// ContainerLogs returns the logs of a pod's container.
+// By default these are the complete logs of the current container, use LogOptions to change this.
+//   logs, err := pod.ContainerLogs("kafka", kubernetes.LogPrevious(), kubernetes.LogTailLines(100))
+func (pod Pod) ContainerLogs(container string, logOptions ...LogOption) ([]byte, error) {
+	config := newLogConfig(logOptions)
 
-// ContainerLogs returns the (current) logs of a pod's container.
-func (pod Pod) ContainerLogs(container string) ([]byte, error) {
 	options := corev1.PodLogOptions{
 		Container: container,
+		Previous:  config.Previous,
+		TailLines: config.TailLines,
+	}
+
+	if pod.client.PodStreams != nil {
+		logs, err := pod.client.PodStreams.Logs(pod.Namespace, pod.Name, options)
+		if err != nil {
+			return []byte{}, fmt.Errorf("failed to get logs of container %s: %w", container, err)
+		}
+
+		return logs, nil
 	}
 
 	result := pod.client.Kubernetes.
@@ -33,13 +65,144 @@ func (pod Pod) ContainerLogs(container string) ([]byte, error) {
 
 // ContainerExec runs a command in a pod's container.
 func (pod Pod) ContainerExec(container string, command cmd.Builder) error {
+	options := remotecommand.StreamOptions{
+		Stdin:  command.Stdin,
+		Stdout: command.Stdout,
+		Stderr: command.Stderr,
+		Tty:    false,
+	}
+
+	exec, err := pod.executor(container, command, options)
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(options)
+}
+
+// ContainerExecWithContext runs a command in a pod's container and captures its output.
+// The context can abort waiting for the command, though the command itself may continue to run in the container.
+// A command exiting with a non-zero exit code results in an ExecExitError.
+// Writers set in the command still receive the output while it is captured.
+//   result, err := pod.ContainerExecWithContext(ctx, "kafka", cmd.New("kafka-topics.sh").
+//   	WithArguments("--list", "--bootstrap-server", "localhost:9092"))
+func (pod Pod) ContainerExecWithContext(
+	ctx context.Context,
+	container string,
+	command cmd.Builder,
+	options ...ExecOption) (ExecResult, error) {
+	config := ExecConfig{}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:             command.Stdin,
+		Stdout:            cmd.CaptureWriter(&stdout, command.Stdout),
+		Tty:               config.TTY,
+		TerminalSizeQueue: config.TerminalSizeQueue,
+	}
+
+	// A TTY multiplexes output and error output on a single stream.
+	if !config.TTY {
+		streamOptions.Stderr = cmd.CaptureWriter(&stderr, command.Stderr)
+	}
+
+	exec, err := pod.executor(container, command, streamOptions)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	err = streamWithContext(ctx, exec, streamOptions)
+	if err != nil && ctx.Err() != nil {
+		// The output buffers may still be written to by the stream, so they can't be returned.
+		return ExecResult{}, fmt.Errorf("failed to execute \"%s\" in container %s: %w", command.Command, container, err)
+	}
+
+	if err != nil {
+		result := ExecResult{
+			Stdout: stdout.Bytes(),
+			Stderr: stderr.Bytes(),
+		}
+
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			result.ExitCode = exitErr.ExitStatus()
+
+			return result, ExecExitError{
+				Container: container,
+				Command:   commandLine(command),
+				ExitCode:  result.ExitCode,
+				Stderr:    result.Stderr,
+			}
+		}
+
+		return result, fmt.Errorf("failed to execute \"%s\" in container %s: %w", command.Command, container, err)
+	}
+
+	return ExecResult{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}, nil
+}
+
+// ContainerExecutor runs commands in a pod's container.
+type ContainerExecutor struct {
+	Pod       Pod
+	Container string
+	Options   []ExecOption
+}
+
+// Executor returns a cmd.Executor that runs commands in a pod's container.
+func (pod Pod) Executor(container string, options ...ExecOption) ContainerExecutor {
+	return ContainerExecutor{
+		Pod:       pod,
+		Container: container,
+		Options:   options,
+	}
+}
+
+// Execute runs a command in the container.
+// The environment and working directory set in the command are applied with 'env' and 'sh',
+// which the container needs to provide in this case.
+// A command exiting with a non-zero exit code returns an ExecExitError, which can be handled as a cmd.ExitError.
+func (executor ContainerExecutor) Execute(ctx context.Context, command cmd.Builder) (cmd.Result, error) {
+	start := time.Now()
+
+	result, err := executor.Pod.ContainerExecWithContext(
+		ctx, executor.Container, containerCommand(command), executor.Options...)
+
+	return cmd.Result{
+		Stdout:   result.Stdout,
+		Stderr:   result.Stderr,
+		ExitCode: result.ExitCode,
+		Duration: time.Since(start),
+	}, err
+}
+
+func (pod Pod) executor(
+	container string,
+	command cmd.Builder,
+	streamOptions remotecommand.StreamOptions) (remotecommand.Executor, error) {
 	options := corev1.PodExecOptions{
 		Container: container,
 		Command:   append([]string{command.Command}, command.Arguments...),
-		Stdin:     command.Stdin != nil,
-		Stdout:    command.Stdout != nil,
-		Stderr:    command.Stderr != nil,
-		TTY:       false,
+		Stdin:     streamOptions.Stdin != nil,
+		Stdout:    streamOptions.Stdout != nil,
+		Stderr:    streamOptions.Stderr != nil,
+		TTY:       streamOptions.Tty,
+	}
+
+	if pod.client.PodStreams != nil {
+		exec, err := pod.client.PodStreams.Exec(pod.Namespace, pod.Name, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute \"%s\" in container %s: %w", command.Command, container, err)
+		}
+
+		return exec, nil
 	}
 
 	// adapted from https://github.com/kubernetes/kubernetes/blob/master/test/e2e/framework/exec_util.go
@@ -53,13 +216,74 @@ func (pod Pod) ContainerExec(container string, command cmd.Builder) error {
 
 	exec, err := remotecommand.NewSPDYExecutor(&pod.client.Config, "POST", req.URL())
 	if err != nil {
-		return fmt.Errorf("failed to execute \"%s\" in container %s: %w", command.Command, container, err)
+		return nil, fmt.Errorf("failed to execute \"%s\" in container %s: %w", command.Command, container, err)
 	}
 
-	return exec.Stream(remotecommand.StreamOptions{
-		Stdin:  command.Stdin,
-		Stdout: command.Stdout,
-		Stderr: command.Stderr,
-		Tty:    false,
+	return exec, nil
+}
+
+// streamWithContext streams until the executor is done or the context is done.
+// The stream isn't closed when the context is done, it will stop once the remote command terminates.
+func streamWithContext(ctx context.Context, exec remotecommand.Executor, options remotecommand.StreamOptions) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- exec.Stream(options)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+// containerCommand wraps a command to apply its environment and working directory, which a container exec
+// doesn't support directly.
+func containerCommand(command cmd.Builder) cmd.Builder {
+	arguments := append([]string{command.Command}, command.Arguments...)
+
+	if len(command.Environment) > 0 || command.CleanEnvironment {
+		environment := append([]string{}, command.Environment...)
+
+		if command.CleanEnvironment {
+			environment = append([]string{"-i"}, environment...)
+		}
+
+		arguments = append(append([]string{"env"}, environment...), arguments...)
+	}
+
+	if command.Directory != "" {
+		arguments = append([]string{"sh", "-c", `cd "$0" && exec "$@"`, command.Directory}, arguments...)
+	}
+
+	command.Command = arguments[0]
+	command.Arguments = arguments[1:]
+	command.Environment = nil
+	command.CleanEnvironment = false
+	command.Directory = ""
+
+	return command
+}
+
+func commandLine(command cmd.Builder) string {
+	return strings.Join(append([]string{command.Command}, command.Arguments...), " ")
+}
+
+// PortForward forwards a local port to a port of the pod.
+// It returns the local address of the forwarded port and a function to stop forwarding.
+// If the pod is replaced, e.g. because it is part of a StatefulSet, forwarding reconnects to the new pod.
+//   address, stop, err := pod.PortForward(9092)
+//   if err != nil ...
+//   defer stop()
+func (pod Pod) PortForward(remotePort int) (string, func(), error) {
+	return forwardPort(pod.client, func() (Pod, int, error) {
+		current, err := GetPod(pod.client, pod.Name, pod.Namespace)
+		if err != nil {
+			return Pod{}, 0, err
+		}
+
+		return current, remotePort, nil
 	})
 }
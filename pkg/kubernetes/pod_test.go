@@ -2,14 +2,19 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
+	"github.com/kudobuilder/test-tools/pkg/cmd"
 )
 
 func TestPod(t *testing.T) {
@@ -38,3 +43,152 @@ func TestPod(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, pods, pod)
 }
+
+type fakeExecutor struct {
+	delay time.Duration
+	err   error
+}
+
+func (e fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	time.Sleep(e.delay)
+
+	return e.err
+}
+
+func TestStreamWithContext(t *testing.T) {
+	streamErr := errors.New("stream failed")
+
+	err := streamWithContext(context.TODO(), fakeExecutor{err: streamErr}, remotecommand.StreamOptions{})
+	assert.Equal(t, streamErr, err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+
+	err = streamWithContext(ctx, fakeExecutor{delay: time.Second}, remotecommand.StreamOptions{})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+var _ cmd.Executor = ContainerExecutor{}
+
+func TestPodFakeStreams(t *testing.T) {
+	const namespace = "test"
+
+	testPod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+		},
+	}
+
+	fake := client.NewFake(&testPod).
+		WithContainerLogs(namespace, testPod.Name, "kafka", []byte("started")).
+		WithPreviousContainerLogs(namespace, testPod.Name, "kafka", []byte("starting\ncrashed\n"))
+
+	fake.Executor.
+		WithCommandResult("kafka-topics.sh", cmd.Result{Stdout: []byte("test-topic\n")}, nil).
+		WithCommandResult("false", cmd.Result{Stderr: []byte("failed"), ExitCode: 1}, nil)
+
+	pod, err := GetPod(fake.Client, testPod.Name, namespace)
+	assert.NoError(t, err)
+
+	logs, err := pod.ContainerLogs("kafka")
+	assert.NoError(t, err)
+	assert.Equal(t, "started", string(logs))
+
+	logs, err = pod.ContainerLogs("kafka", LogPrevious(), LogTailLines(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "crashed\n", string(logs))
+
+	topics := cmd.New("kafka-topics.sh").WithArguments("--list")
+
+	result, err := pod.ContainerExecWithContext(context.TODO(), "kafka", topics)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-topic\n", string(result.Stdout))
+
+	result, err = pod.ContainerExecWithContext(context.TODO(), "kafka", cmd.New("false"))
+	assert.Equal(t, ExecExitError{
+		Container: "kafka",
+		Command:   "false",
+		ExitCode:  1,
+		Stderr:    []byte("failed"),
+	}, err)
+	assert.Equal(t, 1, result.ExitCode)
+}
+
+type stubPodStreams struct {
+	err error
+}
+
+func (streams stubPodStreams) Exec(string, string, corev1.PodExecOptions) (remotecommand.Executor, error) {
+	return streams, nil
+}
+
+func (streams stubPodStreams) Logs(string, string, corev1.PodLogOptions) ([]byte, error) {
+	return nil, nil
+}
+
+func (streams stubPodStreams) Stream(options remotecommand.StreamOptions) error {
+	_, _ = options.Stderr.Write([]byte("no such file"))
+
+	return streams.err
+}
+
+func TestContainerExecWithContext_ExitError(t *testing.T) {
+	pod := Pod{client: client.Client{PodStreams: stubPodStreams{
+		err: utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2},
+	}}}
+
+	result, err := pod.ContainerExecWithContext(context.TODO(), "kafka", cmd.New("ls").WithArguments("/data"))
+	assert.Equal(t, ExecExitError{
+		Container: "kafka",
+		Command:   "ls /data",
+		ExitCode:  2,
+		Stderr:    []byte("no such file"),
+	}, err)
+	assert.Equal(t, 2, result.ExitCode)
+
+	pod = Pod{client: client.Client{PodStreams: stubPodStreams{err: errors.New("connection refused")}}}
+
+	_, err = pod.ContainerExecWithContext(context.TODO(), "kafka", cmd.New("ls"))
+	assert.EqualError(t, err, "failed to execute \"ls\" in container kafka: connection refused")
+	assert.False(t, errors.As(err, &ExecExitError{}))
+}
+
+func TestContainerExecutor(t *testing.T) {
+	fake := client.NewFake(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-0", Namespace: "test"}})
+
+	fake.Executor.
+		WithCommandResult("sh", cmd.Result{}, nil).
+		WithCommandResult("false", cmd.Result{Stderr: []byte("failed"), ExitCode: 1}, nil)
+
+	pod, err := GetPod(fake.Client, "kafka-0", "test")
+	assert.NoError(t, err)
+
+	executor := pod.Executor("kafka")
+
+	command := cmd.New("ls").
+		WithArguments("-l").
+		WithEnvironment(map[string]string{"LC_ALL": "C"}).
+		WithCleanEnvironment().
+		WithDirectory("/data")
+
+	_, err = executor.Execute(context.TODO(), command)
+	assert.NoError(t, err)
+
+	_, err = executor.Execute(context.TODO(), cmd.New("false"))
+
+	var exitErr cmd.ExitError
+	if assert.True(t, errors.As(err, &exitErr)) {
+		assert.Equal(t, cmd.ExitError{Command: "false", ExitCode: 1, Stderr: []byte("failed")}, exitErr)
+	}
+
+	invocations := fake.Executor.Invocations()
+	if assert.Len(t, invocations, 2) {
+		assert.Equal(t, "sh", invocations[0].Command)
+		assert.Equal(t,
+			[]string{"-c", `cd "$0" && exec "$@"`, "/data", "env", "-i", "LC_ALL=C", "ls", "-l"},
+			invocations[0].Arguments)
+		assert.Equal(t, "false", invocations[1].Command)
+		assert.Empty(t, invocations[1].Arguments)
+	}
+}
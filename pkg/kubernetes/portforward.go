@@ -0,0 +1,220 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+const (
+	portForwardAddress       = "127.0.0.1"
+	portForwardCheckInterval = time.Second
+)
+
+// portForwardTarget resolves the pod and its port to forward to.
+// It is called again on reconnects, as a replaced pod may have a different name or port.
+type portForwardTarget func() (Pod, int, error)
+
+// portForwardSession is a single port forwarding connection to a pod.
+type portForwardSession struct {
+	pod       Pod
+	localPort uint16
+	stop      chan struct{}
+	done      chan error
+}
+
+// portForwardStart starts a session forwarding a local port to a port of a pod.
+// A local port of 0 selects a random free port.
+type portForwardStart func(pod Pod, localPort uint16, remotePort int) (*portForwardSession, error)
+
+// portForwarder keeps a local port forwarded to a target, reconnecting if the target pod is replaced.
+type portForwarder struct {
+	client   client.Client
+	target   portForwardTarget
+	start    portForwardStart
+	interval time.Duration
+	stop     chan struct{}
+}
+
+func forwardPort(client client.Client, target portForwardTarget) (string, func(), error) {
+	forwarder := portForwarder{
+		client: client,
+		target: target,
+		start: func(pod Pod, localPort uint16, remotePort int) (*portForwardSession, error) {
+			return startPortForwardSession(client, pod, localPort, remotePort)
+		},
+		interval: portForwardCheckInterval,
+		stop:     make(chan struct{}),
+	}
+
+	pod, remotePort, err := target()
+	if err != nil {
+		return "", nil, err
+	}
+
+	session, err := forwarder.start(pod, 0, remotePort)
+	if err != nil {
+		return "", nil, err
+	}
+
+	go forwarder.keepAlive(session)
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			close(forwarder.stop)
+		})
+	}
+
+	return fmt.Sprintf("%s:%d", portForwardAddress, session.localPort), stop, nil
+}
+
+func startPortForwardSession(
+	client client.Client,
+	pod Pod,
+	localPort uint16,
+	remotePort int) (*portForwardSession, error) {
+	req := client.Kubernetes.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(&client.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward port %d of pod %s in namespace %s: %w",
+			remotePort, pod.Name, pod.Namespace, err)
+	}
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	session := portForwardSession{
+		pod:  pod,
+		stop: make(chan struct{}),
+		done: make(chan error, 1),
+	}
+
+	ready := make(chan struct{})
+
+	forwarder, err := portforward.NewOnAddresses(
+		dialer,
+		[]string{portForwardAddress},
+		[]string{fmt.Sprintf("%d:%d", localPort, remotePort)},
+		session.stop,
+		ready,
+		ioutil.Discard,
+		ioutil.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward port %d of pod %s in namespace %s: %w",
+			remotePort, pod.Name, pod.Namespace, err)
+	}
+
+	go func() {
+		session.done <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err := <-session.done:
+		return nil, fmt.Errorf("failed to forward port %d of pod %s in namespace %s: %w",
+			remotePort, pod.Name, pod.Namespace, err)
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		close(session.stop)
+
+		return nil, fmt.Errorf("failed to forward port %d of pod %s in namespace %s: %w",
+			remotePort, pod.Name, pod.Namespace, err)
+	}
+
+	session.localPort = ports[0].Local
+
+	return &session, nil
+}
+
+func (forwarder portForwarder) keepAlive(session *portForwardSession) {
+	for session != nil {
+		if !forwarder.wait(session) {
+			return
+		}
+
+		session = forwarder.reconnect(session.localPort)
+	}
+}
+
+// wait waits until a session has ended, either because it was stopped or because it lost its pod.
+// It returns false if the session was stopped.
+func (forwarder portForwarder) wait(session *portForwardSession) bool {
+	ticker := time.NewTicker(forwarder.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-forwarder.stop:
+			close(session.stop)
+			<-session.done
+
+			return false
+		case <-session.done:
+			return true
+		case <-ticker.C:
+			if forwarder.replaced(session.pod) {
+				close(session.stop)
+				<-session.done
+
+				return true
+			}
+		}
+	}
+}
+
+// reconnect starts a new session on the same local port once the target is available again.
+func (forwarder portForwarder) reconnect(localPort uint16) *portForwardSession {
+	ticker := time.NewTicker(forwarder.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-forwarder.stop:
+			return nil
+		case <-ticker.C:
+			pod, remotePort, err := forwarder.target()
+			if err != nil || !podRunning(pod.Pod) {
+				continue
+			}
+
+			session, err := forwarder.start(pod, localPort, remotePort)
+			if err != nil {
+				continue
+			}
+
+			return session
+		}
+	}
+}
+
+// replaced checks if a pod was deleted or replaced by a pod with the same name.
+// Other errors getting the pod, e.g. a temporarily unavailable API server, don't end the session.
+func (forwarder portForwarder) replaced(pod Pod) bool {
+	current, err := GetPod(forwarder.client, pod.Name, pod.Namespace)
+	if err != nil {
+		return apierrors.IsNotFound(err)
+	}
+
+	return current.UID != pod.UID || current.DeletionTimestamp != nil
+}
+
+func podRunning(pod corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil
+}
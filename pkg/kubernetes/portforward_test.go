@@ -0,0 +1,116 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func testPortForwardPod(uid string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-0",
+			Namespace: "test",
+			UID:       types.UID(uid),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestPortForwarderReplaced(t *testing.T) {
+	clientset := fake.NewSimpleClientset(testPortForwardPod("1"))
+	forwarder := portForwarder{client: client.Client{Ctx: context.TODO(), Kubernetes: clientset}}
+
+	assert.False(t, forwarder.replaced(Pod{Pod: *testPortForwardPod("1")}))
+	assert.True(t, forwarder.replaced(Pod{Pod: *testPortForwardPod("2")}), "a changed UID should be a replacement")
+
+	clientset.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	assert.False(t, forwarder.replaced(Pod{Pod: *testPortForwardPod("1")}), "errors should not be a replacement")
+
+	clientset = fake.NewSimpleClientset()
+	forwarder = portForwarder{client: client.Client{Ctx: context.TODO(), Kubernetes: clientset}}
+
+	assert.True(t, forwarder.replaced(Pod{Pod: *testPortForwardPod("1")}), "a deleted pod should be a replacement")
+}
+
+func TestPortForwarderReconnect(t *testing.T) {
+	clientset := fake.NewSimpleClientset(testPortForwardPod("1"))
+	testClient := client.Client{Ctx: context.TODO(), Kubernetes: clientset}
+
+	sessions := make(chan *portForwardSession, 2)
+
+	forwarder := portForwarder{
+		client: testClient,
+		target: func() (Pod, int, error) {
+			pod, err := GetPod(testClient, "kafka-0", "test")
+			return pod, 9092, err
+		},
+		start: func(pod Pod, localPort uint16, remotePort int) (*portForwardSession, error) {
+			session := &portForwardSession{
+				pod:       pod,
+				localPort: localPort,
+				stop:      make(chan struct{}),
+				done:      make(chan error, 1),
+			}
+
+			go func() {
+				<-session.stop
+				session.done <- nil
+			}()
+
+			sessions <- session
+
+			return session, nil
+		},
+		interval: time.Millisecond * 10,
+		stop:     make(chan struct{}),
+	}
+
+	session, err := forwarder.start(Pod{Pod: *testPortForwardPod("1")}, 4242, 9092)
+	assert.NoError(t, err)
+	<-sessions
+
+	stopped := make(chan struct{})
+
+	go func() {
+		forwarder.keepAlive(session)
+		close(stopped)
+	}()
+
+	err = clientset.CoreV1().Pods("test").Delete(context.TODO(), "kafka-0", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+
+	_, err = clientset.CoreV1().Pods("test").Create(context.TODO(), testPortForwardPod("2"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	select {
+	case reconnected := <-sessions:
+		assert.Equal(t, types.UID("2"), reconnected.pod.UID)
+		assert.Equal(t, uint16(4242), reconnected.localPort, "reconnects should keep the local port")
+
+		close(forwarder.stop)
+		<-stopped
+
+		select {
+		case <-reconnected.stop:
+		default:
+			assert.Fail(t, "stopping the forwarder should stop the session")
+		}
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "forwarder did not reconnect to the replaced pod")
+	}
+}
@@ -4,9 +4,14 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +33,14 @@ func NewRole(client client.Client, role rbacv1.Role) (Role, error) {
 		return Role{}, fmt.Errorf("failed to create role %s in namespace %s: %w", role.Name, role.Namespace, err)
 	}
 
-	return Role{
-		Role: *createdRole,
+	result := Role{
+		Role:   *createdRole,
 		client: client,
-	}, nil
+	}
+
+	client.Track(fmt.Sprintf("role %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetRole gets a role in a namespace.
@@ -47,7 +56,7 @@ func GetRole(client client.Client, name string, namespace string) (Role, error)
 	}
 
 	return Role{
-		Role: *role,
+		Role:   *role,
 		client: client,
 	}, nil
 }
@@ -68,7 +77,7 @@ func ListRoles(client client.Client, namespace string) ([]Role, error) {
 
 	for _, item := range list.Items {
 		roles = append(roles, Role{
-			Role: item,
+			Role:   item,
 			client: client,
 		})
 	}
@@ -76,6 +85,89 @@ func ListRoles(client client.Client, namespace string) ([]Role, error) {
 	return roles, nil
 }
 
+// RoleEvent is an event received while watching roles.
+type RoleEvent struct {
+	Type watch.EventType
+	Role Role
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchRoles watches roles in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchRoles(client client.Client, namespace string, options metav1.ListOptions) (<-chan RoleEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		RbacV1().
+		Roles(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch roles in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan RoleEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := RoleEvent{Type: event.Type}
+
+			object, ok := event.Object.(*rbacv1.Role)
+			if ok {
+				result.Role = Role{
+					Role:   *object,
+					client: client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch roles in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the Role, ordered by their first occurrence.
+// Events of an earlier role with the same name are not included.
+func (role Role) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		role.client,
+		role.Namespace,
+		EventInvolvedObject("Role", role.Name),
+		EventInvolvedUID(role.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a Role from the Kubernetes cluster.
 func (role Role) Delete() error {
 	options := metav1.DeleteOptions{}
@@ -4,9 +4,14 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +33,14 @@ func NewRoleBinding(client client.Client, rolebinding rbacv1.RoleBinding) (RoleB
 		return RoleBinding{}, fmt.Errorf("failed to create rolebinding %s in namespace %s: %w", rolebinding.Name, rolebinding.Namespace, err)
 	}
 
-	return RoleBinding{
+	result := RoleBinding{
 		RoleBinding: *createdRoleBinding,
-		client: client,
-	}, nil
+		client:      client,
+	}
+
+	client.Track(fmt.Sprintf("rolebinding %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetRoleBinding gets a rolebinding in a namespace.
@@ -48,7 +57,7 @@ func GetRoleBinding(client client.Client, name string, namespace string) (RoleBi
 
 	return RoleBinding{
 		RoleBinding: *rolebinding,
-		client: client,
+		client:      client,
 	}, nil
 }
 
@@ -69,13 +78,96 @@ func ListRoleBindings(client client.Client, namespace string) ([]RoleBinding, er
 	for _, item := range list.Items {
 		rolebindings = append(rolebindings, RoleBinding{
 			RoleBinding: item,
-			client: client,
+			client:      client,
 		})
 	}
 
 	return rolebindings, nil
 }
 
+// RoleBindingEvent is an event received while watching rolebindings.
+type RoleBindingEvent struct {
+	Type        watch.EventType
+	RoleBinding RoleBinding
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchRoleBindings watches rolebindings in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchRoleBindings(client client.Client, namespace string, options metav1.ListOptions) (<-chan RoleBindingEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		RbacV1().
+		RoleBindings(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch rolebindings in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan RoleBindingEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := RoleBindingEvent{Type: event.Type}
+
+			object, ok := event.Object.(*rbacv1.RoleBinding)
+			if ok {
+				result.RoleBinding = RoleBinding{
+					RoleBinding: *object,
+					client:      client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch rolebindings in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the RoleBinding, ordered by their first occurrence.
+// Events of an earlier rolebinding with the same name are not included.
+func (rolebinding RoleBinding) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		rolebinding.client,
+		rolebinding.Namespace,
+		EventInvolvedObject("RoleBinding", rolebinding.Name),
+		EventInvolvedUID(rolebinding.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a RoleBinding from the Kubernetes cluster.
 func (rolebinding RoleBinding) Delete() error {
 	options := metav1.DeleteOptions{}
@@ -1,3 +0,0 @@
-package kubernetes
-
-//go:generate stub-gen -api RbacV1 -type RoleBinding
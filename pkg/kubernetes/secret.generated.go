@@ -4,9 +4,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +32,14 @@ func NewSecret(client client.Client, secret corev1.Secret) (Secret, error) {
 		return Secret{}, fmt.Errorf("failed to create secret %s in namespace %s: %w", secret.Name, secret.Namespace, err)
 	}
 
-	return Secret{
+	result := Secret{
 		Secret: *createdSecret,
 		client: client,
-	}, nil
+	}
+
+	client.Track(fmt.Sprintf("secret %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetSecret gets a secret in a namespace.
@@ -76,6 +84,89 @@ func ListSecrets(client client.Client, namespace string) ([]Secret, error) {
 	return secrets, nil
 }
 
+// SecretEvent is an event received while watching secrets.
+type SecretEvent struct {
+	Type   watch.EventType
+	Secret Secret
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchSecrets watches secrets in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchSecrets(client client.Client, namespace string, options metav1.ListOptions) (<-chan SecretEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		Secrets(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch secrets in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan SecretEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := SecretEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.Secret)
+			if ok {
+				result.Secret = Secret{
+					Secret: *object,
+					client: client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch secrets in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the Secret, ordered by their first occurrence.
+// Events of an earlier secret with the same name are not included.
+func (secret Secret) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		secret.client,
+		secret.Namespace,
+		EventInvolvedObject("Secret", secret.Name),
+		EventInvolvedUID(secret.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a Secret from the Kubernetes cluster.
 func (secret Secret) Delete() error {
 	options := metav1.DeleteOptions{}
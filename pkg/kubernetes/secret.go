@@ -7,8 +7,6 @@ import (
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
 
-//go:generate stub-gen -api CoreV1 -type Secret
-
 // SecretBuilder tracks the options set for a secret.
 type SecretBuilder struct {
 	Name       string
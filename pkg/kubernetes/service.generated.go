@@ -4,9 +4,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +32,14 @@ func NewService(client client.Client, service corev1.Service) (Service, error) {
 		return Service{}, fmt.Errorf("failed to create service %s in namespace %s: %w", service.Name, service.Namespace, err)
 	}
 
-	return Service{
+	result := Service{
 		Service: *createdService,
-		client: client,
-	}, nil
+		client:  client,
+	}
+
+	client.Track(fmt.Sprintf("service %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetService gets a service in a namespace.
@@ -48,7 +56,7 @@ func GetService(client client.Client, name string, namespace string) (Service, e
 
 	return Service{
 		Service: *service,
-		client: client,
+		client:  client,
 	}, nil
 }
 
@@ -69,13 +77,96 @@ func ListServices(client client.Client, namespace string) ([]Service, error) {
 	for _, item := range list.Items {
 		services = append(services, Service{
 			Service: item,
-			client: client,
+			client:  client,
 		})
 	}
 
 	return services, nil
 }
 
+// ServiceEvent is an event received while watching services.
+type ServiceEvent struct {
+	Type    watch.EventType
+	Service Service
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchServices watches services in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchServices(client client.Client, namespace string, options metav1.ListOptions) (<-chan ServiceEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		Services(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch services in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan ServiceEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := ServiceEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.Service)
+			if ok {
+				result.Service = Service{
+					Service: *object,
+					client:  client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch services in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the Service, ordered by their first occurrence.
+// Events of an earlier service with the same name are not included.
+func (service Service) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		service.client,
+		service.Namespace,
+		EventInvolvedObject("Service", service.Name),
+		EventInvolvedUID(service.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a Service from the Kubernetes cluster.
 func (service Service) Delete() error {
 	options := metav1.DeleteOptions{}
@@ -1,3 +1,97 @@
 package kubernetes
 
-//go:generate stub-gen -api CoreV1 -type Service
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PortForward forwards a local port to a port of the service.
+// The port is forwarded to a running pod selected by the service. If this pod goes away,
+// forwarding reconnects to another pod of the service.
+// It returns the local address of the forwarded port and a function to stop forwarding.
+func (service Service) PortForward(port int) (string, func(), error) {
+	return forwardPort(service.client, func() (Pod, int, error) {
+		// The service is updated on reconnects, as its selector or ports may have changed.
+		current, err := GetService(service.client, service.Name, service.Namespace)
+		if err != nil {
+			return Pod{}, 0, err
+		}
+
+		return current.portForwardTarget(port)
+	})
+}
+
+func (service Service) portForwardTarget(port int) (Pod, int, error) {
+	var servicePort *corev1.ServicePort
+
+	for i := range service.Spec.Ports {
+		if int(service.Spec.Ports[i].Port) == port {
+			servicePort = &service.Spec.Ports[i]
+		}
+	}
+
+	if servicePort == nil {
+		return Pod{}, 0, fmt.Errorf("service %s in namespace %s has no port %d", service.Name, service.Namespace, port)
+	}
+
+	if len(service.Spec.Selector) == 0 {
+		return Pod{}, 0, fmt.Errorf("service %s in namespace %s has no selector", service.Name, service.Namespace)
+	}
+
+	options := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	}
+
+	list, err := service.client.Kubernetes.
+		CoreV1().
+		Pods(service.Namespace).
+		List(service.client.Ctx, options)
+	if err != nil {
+		return Pod{}, 0, fmt.Errorf("failed to list pods of service %s in namespace %s: %w",
+			service.Name, service.Namespace, err)
+	}
+
+	for _, item := range list.Items {
+		if !podRunning(item) {
+			continue
+		}
+
+		targetPort, ok := containerPort(item, servicePort.TargetPort, port)
+		if !ok {
+			continue
+		}
+
+		return Pod{
+			Pod:    item,
+			client: service.client,
+		}, targetPort, nil
+	}
+
+	return Pod{}, 0, fmt.Errorf("service %s in namespace %s has no running pod for port %d",
+		service.Name, service.Namespace, port)
+}
+
+// containerPort resolves the target port of a service in a pod.
+func containerPort(pod corev1.Pod, targetPort intstr.IntOrString, port int) (int, bool) {
+	switch {
+	case targetPort.Type == intstr.String:
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == targetPort.StrVal {
+					return int(containerPort.ContainerPort), true
+				}
+			}
+		}
+
+		return 0, false
+	case targetPort.IntVal == 0:
+		// An unset target port defaults to the service port.
+		return port, true
+	default:
+		return int(targetPort.IntVal), true
+	}
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func TestServicePortForwardTarget(t *testing.T) {
+	const namespace = "test"
+
+	labels := map[string]string{"app": "kafka"}
+
+	pendingPod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-0",
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+		},
+	}
+
+	runningPod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-1",
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "kafka",
+					Ports: []corev1.ContainerPort{
+						{Name: "client", ContainerPort: 9093},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	testService := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka",
+			Namespace: namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Port: 9092, TargetPort: intstr.FromString("client")},
+				{Port: 8080, TargetPort: intstr.FromInt(80)},
+				{Port: 7070},
+			},
+		},
+	}
+
+	client := client.Client{
+		Ctx: context.TODO(),
+		Kubernetes: fake.NewSimpleClientset(
+			testService.DeepCopyObject(), pendingPod.DeepCopyObject(), runningPod.DeepCopyObject()),
+	}
+
+	service, err := GetService(client, testService.Name, namespace)
+	assert.NoError(t, err)
+
+	pod, port, err := service.portForwardTarget(9092)
+	assert.NoError(t, err)
+	assert.Equal(t, runningPod.Name, pod.Name)
+	assert.Equal(t, 9093, port)
+
+	_, port, err = service.portForwardTarget(8080)
+	assert.NoError(t, err)
+	assert.Equal(t, 80, port)
+
+	_, port, err = service.portForwardTarget(7070)
+	assert.NoError(t, err)
+	assert.Equal(t, 7070, port)
+
+	_, _, err = service.portForwardTarget(1234)
+	assert.EqualError(t, err, "service kafka in namespace test has no port 1234")
+}
@@ -4,9 +4,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +32,14 @@ func NewServiceAccount(client client.Client, serviceaccount corev1.ServiceAccoun
 		return ServiceAccount{}, fmt.Errorf("failed to create serviceaccount %s in namespace %s: %w", serviceaccount.Name, serviceaccount.Namespace, err)
 	}
 
-	return ServiceAccount{
+	result := ServiceAccount{
 		ServiceAccount: *createdServiceAccount,
-		client: client,
-	}, nil
+		client:         client,
+	}
+
+	client.Track(fmt.Sprintf("serviceaccount %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetServiceAccount gets a serviceaccount in a namespace.
@@ -48,7 +56,7 @@ func GetServiceAccount(client client.Client, name string, namespace string) (Ser
 
 	return ServiceAccount{
 		ServiceAccount: *serviceaccount,
-		client: client,
+		client:         client,
 	}, nil
 }
 
@@ -69,13 +77,96 @@ func ListServiceAccounts(client client.Client, namespace string) ([]ServiceAccou
 	for _, item := range list.Items {
 		serviceaccounts = append(serviceaccounts, ServiceAccount{
 			ServiceAccount: item,
-			client: client,
+			client:         client,
 		})
 	}
 
 	return serviceaccounts, nil
 }
 
+// ServiceAccountEvent is an event received while watching serviceaccounts.
+type ServiceAccountEvent struct {
+	Type           watch.EventType
+	ServiceAccount ServiceAccount
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchServiceAccounts watches serviceaccounts in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchServiceAccounts(client client.Client, namespace string, options metav1.ListOptions) (<-chan ServiceAccountEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		CoreV1().
+		ServiceAccounts(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch serviceaccounts in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan ServiceAccountEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := ServiceAccountEvent{Type: event.Type}
+
+			object, ok := event.Object.(*corev1.ServiceAccount)
+			if ok {
+				result.ServiceAccount = ServiceAccount{
+					ServiceAccount: *object,
+					client:         client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch serviceaccounts in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the ServiceAccount, ordered by their first occurrence.
+// Events of an earlier serviceaccount with the same name are not included.
+func (serviceaccount ServiceAccount) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		serviceaccount.client,
+		serviceaccount.Namespace,
+		EventInvolvedObject("ServiceAccount", serviceaccount.Name),
+		EventInvolvedUID(serviceaccount.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a ServiceAccount from the Kubernetes cluster.
 func (serviceaccount ServiceAccount) Delete() error {
 	options := metav1.DeleteOptions{}
@@ -4,9 +4,14 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/kudobuilder/test-tools/pkg/client"
 )
@@ -28,10 +33,14 @@ func NewStatefulSet(client client.Client, statefulset appsv1.StatefulSet) (State
 		return StatefulSet{}, fmt.Errorf("failed to create statefulset %s in namespace %s: %w", statefulset.Name, statefulset.Namespace, err)
 	}
 
-	return StatefulSet{
+	result := StatefulSet{
 		StatefulSet: *createdStatefulSet,
-		client: client,
-	}, nil
+		client:      client,
+	}
+
+	client.Track(fmt.Sprintf("statefulset %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
 }
 
 // GetStatefulSet gets a statefulset in a namespace.
@@ -48,7 +57,7 @@ func GetStatefulSet(client client.Client, name string, namespace string) (Statef
 
 	return StatefulSet{
 		StatefulSet: *statefulset,
-		client: client,
+		client:      client,
 	}, nil
 }
 
@@ -69,13 +78,96 @@ func ListStatefulSets(client client.Client, namespace string) ([]StatefulSet, er
 	for _, item := range list.Items {
 		statefulsets = append(statefulsets, StatefulSet{
 			StatefulSet: item,
-			client: client,
+			client:      client,
 		})
 	}
 
 	return statefulsets, nil
 }
 
+// StatefulSetEvent is an event received while watching statefulsets.
+type StatefulSetEvent struct {
+	Type        watch.EventType
+	StatefulSet StatefulSet
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchStatefulSets watches statefulsets in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchStatefulSets(client client.Client, namespace string, options metav1.ListOptions) (<-chan StatefulSetEvent, func(), error) {
+	watcher, err := client.Kubernetes.
+		AppsV1().
+		StatefulSets(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch statefulsets in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan StatefulSetEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := StatefulSetEvent{Type: event.Type}
+
+			object, ok := event.Object.(*appsv1.StatefulSet)
+			if ok {
+				result.StatefulSet = StatefulSet{
+					StatefulSet: *object,
+					client:      client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch statefulsets in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the StatefulSet, ordered by their first occurrence.
+// Events of an earlier statefulset with the same name are not included.
+func (statefulset StatefulSet) Events() ([]corev1.Event, error) {
+	events, err := ListEvents(
+		statefulset.client,
+		statefulset.Namespace,
+		EventInvolvedObject("StatefulSet", statefulset.Name),
+		EventInvolvedUID(statefulset.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventFirstTime(events[i]).Before(EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
 // Delete deletes a StatefulSet from the Kubernetes cluster.
 func (statefulset StatefulSet) Delete() error {
 	options := metav1.DeleteOptions{}
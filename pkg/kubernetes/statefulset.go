@@ -1,3 +0,0 @@
-package kubernetes
-
-//go:generate stub-gen -api AppsV1 -type StatefulSet
@@ -0,0 +1,304 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+// Unstructured wraps a Kubernetes object of any kind, e.g. a custom resource.
+// Objects are addressed either by their kind or by their resource name, e.g. "servicemonitors.monitoring.coreos.com".
+type Unstructured struct {
+	unstructured.Unstructured
+
+	resource   schema.GroupVersionResource
+	namespaced bool
+
+	client client.Client
+}
+
+// NewUnstructured creates an Unstructured from its Kubernetes object.
+// The kind of the object is taken from its 'apiVersion' and 'kind'.
+func NewUnstructured(client client.Client, object unstructured.Unstructured) (Unstructured, error) {
+	gvk := object.GroupVersionKind()
+
+	mapping, err := restMapping(client, gvk)
+	if err != nil {
+		return Unstructured{}, fmt.Errorf("failed to create %s %s: %w", gvk.Kind, object.GetName(), err)
+	}
+
+	result := Unstructured{
+		Unstructured: object,
+		resource:     mapping.Resource,
+		namespaced:   mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+		client:       client,
+	}
+
+	resource, err := result.resourceInterface()
+	if err != nil {
+		return Unstructured{}, fmt.Errorf("failed to create %s: %w", result, err)
+	}
+
+	created, err := resource.Create(client.Ctx, &object, metav1.CreateOptions{})
+	if err != nil {
+		return Unstructured{}, fmt.Errorf("failed to create %s: %w", result, err)
+	}
+
+	result.Unstructured = *created
+
+	client.Track(result.String(), result.Delete)
+
+	return result, nil
+}
+
+// GetUnstructured gets an object of a kind.
+// The namespace is ignored for cluster-scoped kinds.
+func GetUnstructured(
+	client client.Client,
+	gvk schema.GroupVersionKind,
+	name string,
+	namespace string) (Unstructured, error) {
+	result, err := newUnstructuredForKind(client, gvk, name, namespace)
+	if err != nil {
+		return Unstructured{}, fmt.Errorf("failed to get %s %s: %w", gvk.Kind, name, err)
+	}
+
+	if err := result.Update(); err != nil {
+		return Unstructured{}, err
+	}
+
+	return result, nil
+}
+
+// GetUnstructuredResource gets an object of a resource, e.g. "servicemonitors.monitoring.coreos.com".
+// The namespace is ignored for cluster-scoped resources.
+func GetUnstructuredResource(
+	client client.Client,
+	resource string,
+	name string,
+	namespace string) (Unstructured, error) {
+	gvk, err := kindForResource(client, resource)
+	if err != nil {
+		return Unstructured{}, fmt.Errorf("failed to get %s %s: %w", resource, name, err)
+	}
+
+	return GetUnstructured(client, gvk, name, namespace)
+}
+
+// ListUnstructured lists all objects of a kind in a namespace.
+// The namespace is ignored for cluster-scoped kinds.
+func ListUnstructured(client client.Client, gvk schema.GroupVersionKind, namespace string) ([]Unstructured, error) {
+	template, err := newUnstructuredForKind(client, gvk, "", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+	}
+
+	resource, err := template.resourceInterface()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", template.resource.Resource, err)
+	}
+
+	list, err := resource.List(client.Ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", template.resource.Resource, err)
+	}
+
+	objects := make([]Unstructured, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		object := template
+		object.Unstructured = item
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// ListUnstructuredResource lists all objects of a resource, e.g. "servicemonitors.monitoring.coreos.com".
+// The namespace is ignored for cluster-scoped resources.
+func ListUnstructuredResource(client client.Client, resource string, namespace string) ([]Unstructured, error) {
+	gvk, err := kindForResource(client, resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+
+	return ListUnstructured(client, gvk, namespace)
+}
+
+// String describes the object by its resource, name and namespace.
+func (object Unstructured) String() string {
+	if object.namespaced {
+		return fmt.Sprintf("%s %s in namespace %s", object.resource.Resource, object.GetName(), object.GetNamespace())
+	}
+
+	return fmt.Sprintf("%s %s", object.resource.Resource, object.GetName())
+}
+
+// Delete deletes the object from the Kubernetes cluster.
+func (object Unstructured) Delete() error {
+	resource, err := object.resourceInterface()
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", object, err)
+	}
+
+	err = resource.Delete(object.client.Ctx, object.GetName(), metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", object, err)
+	}
+
+	return nil
+}
+
+// Update gets the current object status.
+func (object *Unstructured) Update() error {
+	resource, err := object.resourceInterface()
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", object, err)
+	}
+
+	update, err := resource.Get(object.client.Ctx, object.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", object, err)
+	}
+
+	object.Unstructured = *update
+
+	return nil
+}
+
+// Save saves the current object.
+func (object *Unstructured) Save() error {
+	resource, err := object.resourceInterface()
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", object, err)
+	}
+
+	update, err := resource.Update(object.client.Ctx, &object.Unstructured, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", object, err)
+	}
+
+	object.Unstructured = *update
+
+	return nil
+}
+
+// WaitFor waits until a condition on the object is met.
+// The object is updated until the condition is met or the wait times out.
+// By default it waits for 5 minutes unless overridden with a WaitTimeout.
+//   err := serviceMonitor.WaitFor(func(object kubernetes.Unstructured) bool {
+//   	_, found, _ := unstructured.NestedSlice(object.Object, "spec", "endpoints")
+//   	return found
+//   })
+func (object *Unstructured) WaitFor(condition func(Unstructured) bool, options ...WaitOption) error {
+	config := newWaitConfig(options)
+
+	ctx, cancel := context.WithTimeout(object.client.Ctx, config.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(config.Retry)
+	defer ticker.Stop()
+
+	for {
+		if err := object.Update(); err != nil {
+			return err
+		}
+
+		if condition(*object) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to wait for %s: %w", object, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForDeletion waits until the object has been deleted.
+// By default it waits for 5 minutes unless overridden with a WaitTimeout.
+func (object Unstructured) WaitForDeletion(options ...WaitOption) error {
+	err := object.WaitFor(func(Unstructured) bool { return false }, options...)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for deletion of %s", object)
+	}
+
+	return err
+}
+
+func (object Unstructured) resourceInterface() (dynamic.ResourceInterface, error) {
+	if object.client.Dynamic == nil {
+		return nil, fmt.Errorf("client has no dynamic client")
+	}
+
+	resource := object.client.Dynamic.Resource(object.resource)
+
+	if object.namespaced {
+		return resource.Namespace(object.GetNamespace()), nil
+	}
+
+	return resource, nil
+}
+
+func newUnstructuredForKind(
+	client client.Client,
+	gvk schema.GroupVersionKind,
+	name string,
+	namespace string) (Unstructured, error) {
+	mapping, err := restMapping(client, gvk)
+	if err != nil {
+		return Unstructured{}, err
+	}
+
+	result := Unstructured{
+		resource:   mapping.Resource,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+		client:     client,
+	}
+
+	result.SetGroupVersionKind(gvk)
+	result.SetName(name)
+
+	if result.namespaced {
+		result.SetNamespace(namespace)
+	}
+
+	return result, nil
+}
+
+func restMapping(client client.Client, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	if client.RESTMapper == nil {
+		return nil, fmt.Errorf("client has no REST mapper")
+	}
+
+	return client.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+func kindForResource(client client.Client, resource string) (schema.GroupVersionKind, error) {
+	if client.RESTMapper == nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("client has no REST mapper")
+	}
+
+	gvr, err := client.RESTMapper.ResourceFor(schema.ParseGroupResource(resource).WithVersion(""))
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+
+	return client.RESTMapper.KindFor(gvr)
+}
@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func TestUnstructured(t *testing.T) {
+	const namespace = "test"
+
+	gvk := schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+	serviceMonitor := unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(gvk)
+	serviceMonitor.SetName("kafka")
+	serviceMonitor.SetNamespace(namespace)
+
+	fake := client.NewFake(serviceMonitor.DeepCopy())
+
+	object, err := GetUnstructured(fake.Client, gvk, "kafka", namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, "servicemonitors kafka in namespace test", object.String())
+
+	objects, err := ListUnstructuredResource(fake.Client, "servicemonitors.monitoring.coreos.com", namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, []Unstructured{object}, objects)
+
+	object.SetLabels(map[string]string{"release": "prometheus"})
+	assert.NoError(t, object.Save())
+
+	object, err = GetUnstructuredResource(fake.Client, "servicemonitors", "kafka", namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"release": "prometheus"}, object.GetLabels())
+
+	err = object.WaitFor(func(object Unstructured) bool {
+		return object.GetLabels()["release"] == "prometheus"
+	}, WaitTimeout(time.Second))
+	assert.NoError(t, err)
+
+	assert.NoError(t, object.Delete())
+	assert.NoError(t, object.WaitForDeletion(WaitTimeout(time.Second)))
+
+	created, err := NewUnstructured(fake.Client, serviceMonitor)
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka", created.GetName())
+}
+
+func TestUnstructured_IncompleteClient(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+	_, err := GetUnstructured(client.Client{}, gvk, "kafka", "test")
+	assert.EqualError(t, err, "failed to get ServiceMonitor kafka: client has no REST mapper")
+
+	_, err = ListUnstructuredResource(client.Client{}, "servicemonitors", "test")
+	assert.EqualError(t, err, "failed to list servicemonitors: client has no REST mapper")
+
+	serviceMonitor := unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(gvk)
+	serviceMonitor.SetName("kafka")
+	serviceMonitor.SetNamespace("test")
+
+	withoutDynamic := client.NewFake(&serviceMonitor).Client
+	withoutDynamic.Dynamic = nil
+
+	_, err = GetUnstructured(withoutDynamic, gvk, "kafka", "test")
+	assert.EqualError(t, err, "failed to update servicemonitors kafka in namespace test: client has no dynamic client")
+}
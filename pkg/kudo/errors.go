@@ -2,6 +2,7 @@ package kudo
 
 import (
 	"fmt"
+	"strings"
 
 	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 )
@@ -29,3 +30,20 @@ func (PlanStatusTimeout) Timeout() bool { return true }
 
 // Temporary indicates that this is a temporary error.
 func (PlanStatusTimeout) Temporary() bool { return true }
+
+// LeakedResources is the error returned when resources of an Instance still exist after uninstalling its operator.
+type LeakedResources struct {
+	Instance  string
+	Namespace string
+	Resources []string
+}
+
+// Error returns a pretty-printed error string.
+func (l LeakedResources) Error() string {
+	return fmt.Sprintf(
+		"found %d leaked resources of Instance %s in namespace %s: [%s]",
+		len(l.Resources),
+		l.Instance,
+		l.Namespace,
+		strings.Join(l.Resources, ", "))
+}
@@ -64,3 +64,29 @@ func TestWaitTimeout(t *testing.T) {
 	assert.EqualError(t, err, "timed out waiting for plan deploy to have COMPLETE status; current plan status is NEVER_RUN with message \"\"") //nolint:lll
 	assert.True(t, time.Now().Before(deadline))
 }
+
+func TestWaitForPlanProgress(t *testing.T) {
+	const namespace = "test"
+
+	testInstance := kudov1beta1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: namespace,
+		},
+	}
+
+	fake := client.NewFake(&testInstance)
+	fake.ProgressPlan(namespace, testInstance.Name, "deploy",
+		kudov1beta1.ExecutionNeverRun, kudov1beta1.ExecutionInProgress, kudov1beta1.ExecutionComplete)
+
+	instance, err := GetInstance(fake.Client, testInstance.Name, namespace)
+	assert.NoError(t, err)
+
+	config := WaitConfig{
+		Timeout: time.Second,
+		Retry:   time.Millisecond,
+	}
+
+	err = instance.WaitForPlanInStatus("deploy", kudov1beta1.ExecutionComplete, config)
+	assert.NoError(t, err)
+}
@@ -1,2 +1,4 @@
 // Package kudo implements helpers around KUDO operators.
 package kudo
+
+//go:generate stub-gen -config stub-gen.yaml
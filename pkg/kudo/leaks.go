@@ -0,0 +1,299 @@
+package kudo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	kudolabels "github.com/kudobuilder/kudo/pkg/util/kudo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+	"github.com/kudobuilder/test-tools/pkg/debug"
+)
+
+// LeakCheckBuilder tracks the options set for checking an operator uninstall for leaked resources.
+type LeakCheckBuilder struct {
+	// Allowed are patterns of resources that may remain after the uninstall, see WithAllowed.
+	Allowed []string
+}
+
+// LeakCheck records the resources of an Instance before its operator is uninstalled,
+// to verify that they have been removed afterwards.
+type LeakCheck struct {
+	Instance  string
+	Namespace string
+	// Resources are the resources owned by or labeled for the Instance, keyed like in a debug.Snapshot,
+	// e.g. "Service/kafka-svc" or "PersistentVolumeClaim/data-kafka-0".
+	Resources []string
+	Allowed   []string
+
+	recorded []recordedResource
+	client   client.Client
+}
+
+// recordedResource identifies a recorded resource by its UID, so that a resource created with the same name
+// after the uninstall isn't taken for it.
+type recordedResource struct {
+	key      string
+	resource schema.GroupVersionResource
+	uid      types.UID
+}
+
+// BuildLeakCheck creates a builder for checking an operator uninstall for leaked resources.
+// Resources created by the Instance of the operator are recorded before uninstalling it, e.g.
+//   check, err := kudo.BuildLeakCheck().
+//   	WithAllowed("PersistentVolumeClaim/*").
+//   	Do(operator)
+//   if err != nil ...
+//   err = operator.Uninstall()
+//   if err != nil ...
+//   err = check.Verify()
+func BuildLeakCheck() LeakCheckBuilder {
+	return LeakCheckBuilder{}
+}
+
+// WithAllowed allows resources to remain after the uninstall, e.g. retained persistent volume claims.
+// Patterns are matched against the keys of the resources using path.Match, e.g. "PersistentVolumeClaim/*"
+// or "ConfigMap/kafka-config".
+func (builder LeakCheckBuilder) WithAllowed(patterns ...string) LeakCheckBuilder {
+	builder.Allowed = append(append([]string{}, builder.Allowed...), patterns...)
+	return builder
+}
+
+// Do records the resources owned by or labeled for the Instance of an operator.
+// Resources owned by recorded resources, e.g. the Pods of a StatefulSet, are recorded as well.
+func (builder LeakCheckBuilder) Do(operator Operator) (LeakCheck, error) {
+	for _, pattern := range builder.Allowed {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return LeakCheck{}, fmt.Errorf("invalid pattern %q of allowed resources: %w", pattern, err)
+		}
+	}
+
+	instance := operator.Instance
+
+	if operator.client.RESTMapper == nil {
+		return LeakCheck{}, fmt.Errorf(
+			"failed to record resources of Instance %s in namespace %s: client has no REST mapper",
+			instance.Name, instance.Namespace)
+	}
+
+	snapshot, err := debug.BuildSnapshot(instance.Namespace).Do(operator.client)
+	if err != nil {
+		return LeakCheck{}, fmt.Errorf(
+			"failed to record resources of Instance %s in namespace %s: %w", instance.Name, instance.Namespace, err)
+	}
+
+	check := LeakCheck{
+		Instance:  instance.Name,
+		Namespace: instance.Namespace,
+		Resources: instanceResources(snapshot, instance.Name),
+		Allowed:   builder.Allowed,
+		client:    operator.client,
+	}
+
+	for _, key := range check.Resources {
+		object := snapshot.Objects[key]
+		gvk := object.GroupVersionKind()
+
+		mapping, err := operator.client.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return LeakCheck{}, fmt.Errorf(
+				"failed to record resources of Instance %s in namespace %s: %w", instance.Name, instance.Namespace, err)
+		}
+
+		check.recorded = append(check.recorded, recordedResource{
+			key:      key,
+			resource: mapping.Resource,
+			uid:      object.GetUID(),
+		})
+	}
+
+	return check, nil
+}
+
+// Leaked waits for the recorded resources to be removed and returns those that aren't allowed to remain,
+// but still exist once the wait times out. Resources are identified by their UID: a resource recreated with
+// the same name isn't leaked. Failing to list resources is retried until the wait times out.
+// By default it waits for 2 minutes unless overridden with a WaitTimeout.
+func (check LeakCheck) Leaked(options ...WaitOption) ([]string, error) {
+	if check.client.Dynamic == nil {
+		return nil, fmt.Errorf(
+			"failed to check resources of Instance %s in namespace %s: client has no dynamic client",
+			check.Instance, check.Namespace)
+	}
+
+	config := WaitConfig{
+		Timeout: time.Minute * 2,
+		Retry:   time.Second * 5,
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	ctx, cancel := context.WithTimeout(check.client.Ctx, config.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(config.Retry)
+	defer ticker.Stop()
+
+	for {
+		leaked, err := check.remaining()
+		if err == nil && len(leaked) == 0 {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf(
+					"failed to wait for removal of resources of Instance %s in namespace %s: %w",
+					check.Instance,
+					check.Namespace,
+					ctx.Err())
+			}
+
+			// Errors are only reported if the check keeps failing until the timeout.
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to check resources of Instance %s in namespace %s: %w", check.Instance, check.Namespace, err)
+			}
+
+			return leaked, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// remaining returns the keys of the recorded resources that still exist and aren't allowed to remain.
+// Only the types of the recorded resources are listed.
+func (check LeakCheck) remaining() ([]string, error) {
+	uids := make(map[schema.GroupVersionResource]map[types.UID]bool)
+
+	for _, recorded := range check.recorded {
+		if _, ok := uids[recorded.resource]; ok {
+			continue
+		}
+
+		list, err := check.client.Dynamic.
+			Resource(recorded.resource).
+			Namespace(check.Namespace).
+			List(check.client.Ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", recorded.resource.GroupResource(), err)
+		}
+
+		uids[recorded.resource] = make(map[types.UID]bool, len(list.Items))
+
+		for _, item := range list.Items {
+			uids[recorded.resource][item.GetUID()] = true
+		}
+	}
+
+	var leaked []string
+
+	for _, recorded := range check.recorded {
+		if uids[recorded.resource][recorded.uid] && !check.allowed(recorded.key) {
+			leaked = append(leaked, recorded.key)
+		}
+	}
+
+	return leaked, nil
+}
+
+// Verify waits for the recorded resources to be removed and returns a LeakedResources error listing those
+// that aren't allowed to remain, but still exist once the wait times out.
+// By default it waits for 2 minutes unless overridden with a WaitTimeout.
+func (check LeakCheck) Verify(options ...WaitOption) error {
+	leaked, err := check.Leaked(options...)
+	if err != nil {
+		return err
+	}
+
+	if len(leaked) > 0 {
+		return LeakedResources{
+			Instance:  check.Instance,
+			Namespace: check.Namespace,
+			Resources: leaked,
+		}
+	}
+
+	return nil
+}
+
+func (check LeakCheck) allowed(resource string) bool {
+	for _, pattern := range check.Allowed {
+		if matched, _ := path.Match(pattern, resource); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// instanceResources returns the sorted keys of the objects of a snapshot which are labeled for an Instance or
+// owned by it, directly or through other owned objects.
+func instanceResources(snapshot debug.Snapshot, instance string) []string {
+	owned := make(map[string]bool)
+	ownedUIDs := make(map[types.UID]bool)
+
+	for key, object := range snapshot.Objects {
+		if object.GetLabels()[kudolabels.InstanceLabel] == instance || ownedByInstance(object, instance) {
+			owned[key] = true
+			ownedUIDs[object.GetUID()] = true
+		}
+	}
+
+	// Owned objects can own further objects, e.g. the Pods of a StatefulSet of the Instance.
+	for found := true; found; {
+		found = false
+
+		for key, object := range snapshot.Objects {
+			if owned[key] {
+				continue
+			}
+
+			for _, owner := range object.GetOwnerReferences() {
+				if ownedUIDs[owner.UID] {
+					owned[key] = true
+					ownedUIDs[object.GetUID()] = true
+					found = true
+
+					break
+				}
+			}
+		}
+	}
+
+	resources := make([]string, 0, len(owned))
+
+	for key := range owned {
+		resources = append(resources, key)
+	}
+
+	sort.Strings(resources)
+
+	return resources
+}
+
+func ownedByInstance(object *unstructured.Unstructured, instance string) bool {
+	for _, owner := range object.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			continue
+		}
+
+		if gv.Group == "kudo.dev" && owner.Kind == "Instance" && owner.Name == instance {
+			return true
+		}
+	}
+
+	return false
+}
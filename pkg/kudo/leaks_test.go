@@ -0,0 +1,142 @@
+package kudo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func leakTestObject(apiVersion, kind, name, uid string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace("ns")
+	object.SetName(name)
+	object.SetUID(types.UID(uid))
+
+	return object
+}
+
+func TestLeakCheck(t *testing.T) {
+	service := leakTestObject("v1", "Service", "kafka-svc", "1")
+	service.SetOwnerReferences([]metav1.OwnerReference{
+		{APIVersion: "kudo.dev/v1beta1", Kind: "Instance", Name: "kafka", UID: "0"},
+	})
+
+	statefulSet := leakTestObject("apps/v1", "StatefulSet", "kafka", "2")
+	statefulSet.SetLabels(map[string]string{"kudo.dev/instance": "kafka"})
+
+	pod := leakTestObject("v1", "Pod", "kafka-0", "3")
+	pod.SetOwnerReferences([]metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "kafka", UID: "2"},
+	})
+
+	pvc := leakTestObject("v1", "PersistentVolumeClaim", "data-kafka-0", "4")
+	pvc.SetLabels(map[string]string{"kudo.dev/instance": "kafka"})
+
+	otherService := leakTestObject("v1", "Service", "zookeeper", "5")
+	otherService.SetLabels(map[string]string{"kudo.dev/instance": "zookeeper"})
+
+	fake := client.NewFake(service, statefulSet, pod, pvc, otherService)
+	fake.FakeKubernetes.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+				{Name: "services", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+				{Name: "persistentvolumeclaims", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "statefulsets", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			},
+		},
+	}
+
+	operator := Operator{
+		Name: "kafka",
+		Instance: Instance{
+			Instance: kudov1beta1.Instance{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "ns"}},
+			client:   fake.Client,
+		},
+		client: fake.Client,
+	}
+
+	check, err := BuildLeakCheck().WithAllowed("PersistentVolumeClaim/*").Do(operator)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{
+		"PersistentVolumeClaim/data-kafka-0",
+		"Pod/kafka-0",
+		"Service/kafka-svc",
+		"StatefulSet.apps/kafka",
+	}, check.Resources)
+
+	deleteObject := func(group, resource, name string) {
+		gvr := schema.GroupVersionResource{Group: group, Version: "v1", Resource: resource}
+		assert.NoError(t, fake.FakeDynamic.Resource(gvr).Namespace("ns").Delete(fake.Ctx, name, metav1.DeleteOptions{}))
+	}
+
+	// The uninstall removed everything but the persistent volume claim and the Service.
+	deleteObject("apps", "statefulsets", "kafka")
+	deleteObject("", "pods", "kafka-0")
+
+	err = check.Verify(WaitTimeout(10 * time.Millisecond))
+	assert.EqualError(t, err, "found 1 leaked resources of Instance kafka in namespace ns: [Service/kafka-svc]")
+
+	var leaked LeakedResources
+
+	if assert.True(t, errors.As(err, &leaked)) {
+		assert.Equal(t, []string{"Service/kafka-svc"}, leaked.Resources)
+	}
+
+	listErr := errors.New("forbidden")
+	fake.FakeDynamic.PrependReactor("list", "services", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return listErr != nil, nil, listErr
+	})
+
+	_, err = check.Leaked(WaitTimeout(10 * time.Millisecond))
+	assert.EqualError(t, err,
+		"failed to check resources of Instance kafka in namespace ns: failed to list services: forbidden")
+
+	listErr = nil
+
+	// A Service created with the same name by another Instance isn't leaked.
+	deleteObject("", "services", "kafka-svc")
+
+	_, err = fake.FakeDynamic.
+		Resource(schema.GroupVersionResource{Version: "v1", Resource: "services"}).
+		Namespace("ns").
+		Create(fake.Ctx, leakTestObject("v1", "Service", "kafka-svc", "6"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, check.Verify(WaitTimeout(10*time.Millisecond)))
+}
+
+func TestLeakCheck_InvalidPattern(t *testing.T) {
+	_, err := BuildLeakCheck().WithAllowed("[").Do(Operator{})
+	assert.EqualError(t, err, "invalid pattern \"[\" of allowed resources: syntax error in pattern")
+}
+
+func TestLeakCheck_NoDynamicClient(t *testing.T) {
+	check := LeakCheck{Instance: "kafka", Namespace: "ns"}
+
+	_, err := check.Leaked()
+	assert.EqualError(t, err,
+		"failed to check resources of Instance kafka in namespace ns: client has no dynamic client")
+}
@@ -188,13 +188,23 @@ func (builder OperatorBuilder) Do(client client.Client) (Operator, error) {
 		return Operator{}, fmt.Errorf("failed to install operator %s: %w", builder.Name, err)
 	}
 
-	return newOperator(client, builder.Name, builder.Instance, builder.Namespace)
+	operator, err := newOperator(client, builder.Name, builder.Instance, builder.Namespace)
+	if err != nil {
+		return Operator{}, err
+	}
+
+	client.Track(
+		fmt.Sprintf("operator %s with instance %s in namespace %s", builder.Name, builder.Instance, builder.Namespace),
+		operator.Uninstall)
+
+	return operator, nil
 }
 
 // Uninstall removes the cluster resources of an operator.
 // This will remove the Instance, OperatorVersion and Operator!
 // We assume that this is the intended behavior for most test cases.
 // Don't use this for test cases which have multiple Instances for a single OperatorVersion.
+// Use BuildLeakCheck to verify that the resources created by the Instance are removed as well.
 func (operator Operator) Uninstall() error {
 	return operator.UninstallWaitForDeletion(0)
 }
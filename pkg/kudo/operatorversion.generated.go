@@ -0,0 +1,217 @@
+package kudo
+
+// Code generated by stub-gen; DO NOT EDIT.
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+	"github.com/kudobuilder/test-tools/pkg/kubernetes"
+)
+
+// OperatorVersion wraps a Kudo OperatorVersion.
+type OperatorVersion struct {
+	kudov1beta1.OperatorVersion
+
+	client client.Client
+}
+
+// NewOperatorVersion creates a OperatorVersion from its Kudo OperatorVersion.
+func NewOperatorVersion(client client.Client, operatorversion kudov1beta1.OperatorVersion) (OperatorVersion, error) {
+	createdOperatorVersion, err := client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(operatorversion.Namespace).
+		Create(client.Ctx, &operatorversion, metav1.CreateOptions{})
+	if err != nil {
+		return OperatorVersion{}, fmt.Errorf("failed to create operatorversion %s in namespace %s: %w", operatorversion.Name, operatorversion.Namespace, err)
+	}
+
+	result := OperatorVersion{
+		OperatorVersion: *createdOperatorVersion,
+		client:          client,
+	}
+
+	client.Track(fmt.Sprintf("operatorversion %s in namespace %s", result.Name, result.Namespace), result.Delete)
+
+	return result, nil
+}
+
+// GetOperatorVersion gets a operatorversion in a namespace.
+func GetOperatorVersion(client client.Client, name string, namespace string) (OperatorVersion, error) {
+	options := metav1.GetOptions{}
+
+	operatorversion, err := client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(namespace).
+		Get(client.Ctx, name, options)
+	if err != nil {
+		return OperatorVersion{}, fmt.Errorf("failed to get operatorversion %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return OperatorVersion{
+		OperatorVersion: *operatorversion,
+		client:          client,
+	}, nil
+}
+
+// ListOperatorVersions lists all operatorversions in a namespace.
+func ListOperatorVersions(client client.Client, namespace string) ([]OperatorVersion, error) {
+	options := metav1.ListOptions{}
+
+	list, err := client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(namespace).
+		List(client.Ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operatorversions in namespace %s: %w", namespace, err)
+	}
+
+	operatorversions := make([]OperatorVersion, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		operatorversions = append(operatorversions, OperatorVersion{
+			OperatorVersion: item,
+			client:          client,
+		})
+	}
+
+	return operatorversions, nil
+}
+
+// OperatorVersionEvent is an event received while watching operatorversions.
+type OperatorVersionEvent struct {
+	Type            watch.EventType
+	OperatorVersion OperatorVersion
+	// Err is set if the watch failed, no further events are sent afterwards.
+	Err error
+}
+
+// WatchOperatorVersions watches operatorversions in a namespace.
+// Events are sent to the returned channel until the watch ends or fails, or the returned function is called.
+// If the watch fails, a last event with an error is sent.
+func WatchOperatorVersions(client client.Client, namespace string, options metav1.ListOptions) (<-chan OperatorVersionEvent, func(), error) {
+	watcher, err := client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(namespace).
+		Watch(client.Ctx, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch operatorversions in namespace %s: %w", namespace, err)
+	}
+
+	events := make(chan OperatorVersionEvent)
+	done := make(chan struct{})
+
+	var once sync.Once
+
+	stop := func() {
+		once.Do(func() {
+			watcher.Stop()
+			close(done)
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		for event := range watcher.ResultChan() {
+			result := OperatorVersionEvent{Type: event.Type}
+
+			object, ok := event.Object.(*kudov1beta1.OperatorVersion)
+			if ok {
+				result.OperatorVersion = OperatorVersion{
+					OperatorVersion: *object,
+					client:          client,
+				}
+			} else {
+				// Error events carry a metav1.Status instead of the watched object.
+				result.Err = fmt.Errorf("failed to watch operatorversions in namespace %s: %w", namespace, apierrors.FromObject(event.Object))
+			}
+
+			select {
+			case events <- result:
+			case <-done:
+				return
+			}
+
+			if result.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// Events lists the Kubernetes Events involving the OperatorVersion, ordered by their first occurrence.
+// Events of an earlier operatorversion with the same name are not included.
+func (operatorversion OperatorVersion) Events() ([]corev1.Event, error) {
+	events, err := kubernetes.ListEvents(
+		operatorversion.client,
+		operatorversion.Namespace,
+		kubernetes.EventInvolvedObject("OperatorVersion", operatorversion.Name),
+		kubernetes.EventInvolvedUID(operatorversion.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return kubernetes.EventFirstTime(events[i]).Before(kubernetes.EventFirstTime(events[j]))
+	})
+
+	return events, nil
+}
+
+// Delete deletes a OperatorVersion from the Kubernetes cluster.
+func (operatorversion OperatorVersion) Delete() error {
+	options := metav1.DeleteOptions{}
+
+	err := operatorversion.client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(operatorversion.Namespace).
+		Delete(operatorversion.client.Ctx, operatorversion.Name, options)
+	if err != nil {
+		return fmt.Errorf("failed to delete operatorversion %s in namespace %s: %w", operatorversion.Name, operatorversion.Namespace, err)
+	}
+
+	return nil
+}
+
+// Update gets the current OperatorVersion status.
+func (operatorversion *OperatorVersion) Update() error {
+	options := metav1.GetOptions{}
+
+	update, err := operatorversion.client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(operatorversion.Namespace).
+		Get(operatorversion.client.Ctx, operatorversion.Name, options)
+	if err != nil {
+		return fmt.Errorf("failed to update operatorversion %s in namespace %s: %w", operatorversion.Name, operatorversion.Namespace, err)
+	}
+
+	operatorversion.OperatorVersion = *update
+
+	return nil
+}
+
+// Save saves the current OperatorVersion.
+func (operatorversion *OperatorVersion) Save() error {
+	update, err := operatorversion.client.Kudo.
+		KudoV1beta1().
+		OperatorVersions(operatorversion.Namespace).
+		Update(operatorversion.client.Ctx, &operatorversion.OperatorVersion, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to save operatorversion %s in namespace %s: %w", operatorversion.Name, operatorversion.Namespace, err)
+	}
+
+	operatorversion.OperatorVersion = *update
+
+	return nil
+}
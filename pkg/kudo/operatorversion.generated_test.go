@@ -0,0 +1,171 @@
+package kudo
+
+// Code generated by stub-gen; DO NOT EDIT.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kudobuilder/test-tools/pkg/client"
+)
+
+func TestGeneratedOperatorVersion(t *testing.T) {
+	client := client.Client{
+		Ctx:  context.TODO(),
+		Kudo: fake.NewSimpleClientset(),
+	}
+
+	created, err := NewOperatorVersion(client, kudov1beta1.OperatorVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-operatorversion",
+			Namespace: "test",
+		},
+	})
+	assert.NoError(t, err)
+
+	got, err := GetOperatorVersion(client, created.Name, created.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	list, err := ListOperatorVersions(client, created.Namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, []OperatorVersion{created}, list)
+
+	got.Labels = map[string]string{"generated-by": "stub-gen"}
+	assert.NoError(t, got.Save())
+
+	assert.NoError(t, created.Update())
+	assert.Equal(t, got.Labels, created.Labels)
+
+	assert.NoError(t, created.Delete())
+
+	_, err = GetOperatorVersion(client, created.Name, created.Namespace)
+	assert.Error(t, err)
+}
+
+func TestGeneratedOperatorVersionWatch(t *testing.T) {
+	client := client.Client{
+		Ctx:  context.TODO(),
+		Kudo: fake.NewSimpleClientset(),
+	}
+
+	events, stop, err := WatchOperatorVersions(client, "test", metav1.ListOptions{})
+	assert.NoError(t, err)
+
+	created, err := NewOperatorVersion(client, kudov1beta1.OperatorVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-operatorversion",
+			Namespace: "test",
+		},
+	})
+	assert.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, created, event.OperatorVersion)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for watch event")
+	}
+
+	stop()
+	stop()
+
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestGeneratedOperatorVersionWatchError(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	watcher := watch.NewFake()
+	clientset.PrependWatchReactor("*", k8stesting.DefaultWatchReactor(watcher, nil))
+
+	client := client.Client{
+		Ctx:  context.TODO(),
+		Kudo: clientset,
+	}
+
+	events, stop, err := WatchOperatorVersions(client, "test", metav1.ListOptions{})
+	assert.NoError(t, err)
+
+	defer stop()
+
+	go watcher.Error(&metav1.Status{
+		Status: metav1.StatusFailure,
+		Reason: metav1.StatusReasonExpired,
+		Code:   410,
+	})
+
+	select {
+	case event := <-events:
+		assert.Equal(t, watch.Error, event.Type)
+		assert.True(t, apierrors.IsResourceExpired(event.Err), "unexpected error %v", event.Err)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for watch error")
+	}
+
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestGeneratedOperatorVersionEvents(t *testing.T) {
+	event := func(name string, kind string, involved string, uid types.UID, firstTimestamp time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind: kind,
+				Name: involved,
+				UID:  uid,
+			},
+			FirstTimestamp: metav1.NewTime(firstTimestamp),
+		}
+	}
+
+	now := time.Now()
+
+	client := client.Client{
+		Ctx: context.TODO(),
+		Kubernetes: kubernetesfake.NewSimpleClientset(
+			event("second", "OperatorVersion", "test-operatorversion", "test-uid", now),
+			event("first", "OperatorVersion", "test-operatorversion", "test-uid", now.Add(-time.Minute)),
+			event("other-name", "OperatorVersion", "other", "other-uid", now),
+			event("other-kind", "Other", "test-operatorversion", "test-uid", now),
+			event("other-uid", "OperatorVersion", "test-operatorversion", "earlier-uid", now),
+		),
+		Kudo: fake.NewSimpleClientset(),
+	}
+
+	created, err := NewOperatorVersion(client, kudov1beta1.OperatorVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-operatorversion",
+			Namespace: "test",
+			UID:       "test-uid",
+		},
+	})
+	assert.NoError(t, err)
+
+	events, err := created.Events()
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.Name)
+	}
+
+	assert.Equal(t, []string{"first", "second"}, names)
+}